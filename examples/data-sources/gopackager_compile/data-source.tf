@@ -4,31 +4,69 @@ data "gopackager_compile" "example" {
   source = "src/main.go"
   ## Output destination file.
   destination = "service/bootstrap"
-  ## GOOS for compilation.
-  goos = "linux"
-  ## GOARCH for compilation.
-  goarch = "amd64"
 
   # Optional
-  ## Zip the compiled binary and additional resources.
-  zip = true
-  ## Additional resources to be zipped.
+  ## GOOS for compilation, defaults to the `goos` of the provider.
+  goos = "linux"
+  ## GOARCH for compilation, defaults to the `goarch` of the provider.
+  goarch = "amd64"
+  ## Archive the compiled binary and additional resources (`zip`, `tar.gz` or `tar.zst`).
+  ## `zip = true` is still supported as alias for `archive_format = "zip"`.
+  archive_format = "zip"
+  ## Additional resources to be archived.
   ## {source_path = destination_path}
   zip_resources = {
-    "static"  = "www/static"
-    "LICENSE" = "LICENSE"
+    "static"              = "www/static"
+    "templates/**/*.tmpl" = "templates"
+    "LICENSE"             = "LICENSE"
+  }
+  ## Glob patterns of paths inside of the archive to exclude.
+  zip_excludes = ["**/.DS_Store", "**/*.swp"]
+  ## Overwrite the permissions of files inside of the archive.
+  ## {path_inside_archive = octal_permission}
+  zip_file_modes = {
+    "bootstrap" = "0755"
+    "LICENSE"   = "0644"
   }
   ## Base path to use for hash calculation.
   base_path = "./src"
+  ## Only hash the files the binary is built from instead of the whole base path.
+  hash_mode = "deps"
+  ## Build tags passed to `go build -tags`.
+  tags = ["lambda.norpc", "netgo"]
+  ## Set `CGO_ENABLED` explicitly instead of depending on the host environment.
+  cgo_enabled = false
+  ## Additional environment variables for the build.
+  ## GO* variables of the host are only passed if they configure paths, caches, proxies or private modules.
+  env = {
+    "GOAMD64" = "v3"
+  }
+  ## Build byte-identical binaries for the same source on every machine.
+  reproducible = true
+  ## Additional flags passed to `go build`, replacing the `build_flags` of the provider.
+  build_flags = ["-v"]
+  ## Linker flags passed to `go build -ldflags`.
+  ldflags {
+    ## Set string variables via `-X importpath.name=value`.
+    variables = {
+      "main.version" = "v1.0.0"
+      "main.commit"  = "abc123"
+    }
+    ## Strip the symbol table and debug information (`-s`).
+    strip_symbols = true
+    ## Strip the DWARF symbol table (`-w`).
+    strip_dwarf = true
+  }
 }
 
 output "example" {
   value = {
     # `output_path` provides the path and file name of the compiled binary.
-    # If `zip = true`, this will refer to the zip file.
+    # If `archive_format` is set, this will refer to the archive (e.g. `service/bootstrap.zip`).
     output_path = data.gopackager_compile.example.output_path
     # `output_md5` provides the md5 hash of the source files.
     # If the `base_path` is provided, the hash is calculated based on that path instead of the source's directory.
+    # Build settings like `tags`, `cgo_enabled`, `env`, `ldflags` or `reproducible` are included in the hashes, so changing them produces new hashes as well.
     output_md5 = data.gopackager_compile.example.output_md5
     # `output_sha1` provides the SHA1 hash of the source files.
     # If the `base_path` is provided, the hash is calculated based on that path instead of the source's directory.
@@ -45,10 +83,19 @@ output "example" {
     # `output_sha512_base64` provides the Base64 encoded SHA512 hash of the source files.
     # If the `base_path` is provided, the hash is calculated based on that path instead of the source's directory.
     output_sha512_base64 = data.gopackager_compile.example.output_sha512_base64
+    # `artifact_*` provides the hashes of the file at `output_path` (binary or archive),
+    # e.g. to match the `source_code_hash` of the uploaded file.
+    artifact_md5           = data.gopackager_compile.example.artifact_md5
+    artifact_sha1          = data.gopackager_compile.example.artifact_sha1
+    artifact_sha256        = data.gopackager_compile.example.artifact_sha256
+    artifact_sha512        = data.gopackager_compile.example.artifact_sha512
+    artifact_sha256_base64 = data.gopackager_compile.example.artifact_sha256_base64
+    artifact_sha512_base64 = data.gopackager_compile.example.artifact_sha512_base64
   }
 }
 
 # Example on how to use it with AWS lambda.
+# With `reproducible = true` the artifact hash only changes if the source or build settings change.
 resource "aws_lambda_function" "example" {
   function_name    = "example"
   runtime          = "provided.al2023"
@@ -56,6 +103,56 @@ resource "aws_lambda_function" "example" {
   role             = aws_iam_role.lambda_role.arn
   timeout          = 15
   filename         = data.gopackager_compile.example.output_path
-  source_code_hash = data.gopackager_compile.example.output_sha256_base64
+  source_code_hash = data.gopackager_compile.example.artifact_sha256_base64
   memory_size      = 128
 }
+
+# Example on how to build the same binary for multiple targets.
+data "gopackager_compile" "matrix" {
+  source         = "cmd/cli/main.go"
+  destination    = "dist/cli"
+  archive_format = "tar.gz"
+  ## Maximum number of parallel builds.
+  concurrency = 2
+  ## The binaries are placed in `dist/<goos>_<goarch>[_<variant>]/cli`.
+  targets = [
+    { goos = "linux", goarch = "amd64", variant = "v3" },
+    { goos = "linux", goarch = "arm64" },
+    { goos = "darwin", goarch = "arm64" },
+    { goos = "windows", goarch = "amd64" },
+  ]
+}
+
+output "matrix" {
+  # `target_outputs` provides the `output_path` and `artifact_*` hashes by target.
+  value = data.gopackager_compile.matrix.target_outputs["linux_amd64_v3"].output_path
+}
+
+# Example on how to select the hash algorithms, e.g. for an upload to Google Cloud Storage.
+data "gopackager_compile" "gcs" {
+  source         = "cmd/function/main.go"
+  destination    = "dist/function"
+  archive_format = "zip"
+  ## Only the selected algorithms are computed, the `output_*` and `artifact_*` attributes of others are null.
+  hash_algorithms = ["crc32c", "blake2b_512"]
+}
+
+output "gcs" {
+  # `artifact_hashes` provides the hex and base64 encoded hashes by algorithm.
+  value = data.gopackager_compile.gcs.artifact_hashes["crc32c_base64"]
+}
+
+# Example on how to see which source files changed in `terraform plan`.
+data "gopackager_compile" "manifest" {
+  source      = "cmd/cli/main.go"
+  destination = "dist/cli"
+  ## Lists the digest, size and mode of every hashed file in `source_manifest`.
+  source_manifest_enabled = true
+  ## Writes the same list as JSON after the build.
+  source_manifest_file = "dist/cli.manifest.json"
+}
+
+output "manifest" {
+  # `source_manifest` provides the hashed files by their path relative to the base path.
+  value = data.gopackager_compile.manifest.source_manifest["main.go"].digest
+}
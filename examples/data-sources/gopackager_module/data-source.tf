@@ -0,0 +1,15 @@
+data "gopackager_module" "example" {
+  # Required
+  ## Path of the `go.mod` or of a directory inside of the module.
+  path = "cmd/cli"
+}
+
+locals {
+  # Name and tag artifacts after the module and the Go version.
+  name = basename(data.gopackager_module.example.module_path)
+  tags = {
+    go_version = data.gopackager_module.example.go_version
+    # Only the modules imported by the main module.
+    dependencies = join(",", [for require in data.gopackager_module.example.requires : require.path if !require.indirect])
+  }
+}
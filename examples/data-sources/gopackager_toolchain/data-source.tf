@@ -0,0 +1,22 @@
+data "gopackager_toolchain" "example" {
+  # Optional
+  ## Path or name of the go binary, defaults to the `go_binary` of the provider.
+  go_binary = "go"
+}
+
+# Example on how to require a minimum Go version for the build.
+data "gopackager_compile" "example" {
+  source      = "cmd/cli/main.go"
+  destination = "dist/cli"
+
+  lifecycle {
+    precondition {
+      condition     = split(".", trimprefix(data.gopackager_toolchain.example.go_version, "go"))[1] >= 24
+      error_message = "Go 1.24 or newer is required, but got ${data.gopackager_toolchain.example.go_version}."
+    }
+    precondition {
+      condition     = contains([for port in data.gopackager_toolchain.example.ports : "${port.goos}/${port.goarch}"], "linux/arm64")
+      error_message = "The toolchain doesn't support linux/arm64."
+    }
+  }
+}
@@ -0,0 +1,5 @@
+# Example on how to name resources after the Go module.
+output "module_name" {
+  ## The `go.mod` is looked up in the directory and its parents.
+  value = basename(provider::gopackager::go_module_path("cmd/cli"))
+}
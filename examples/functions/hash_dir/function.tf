@@ -0,0 +1,5 @@
+# Example on how to trigger a deployment on source changes without building.
+output "source_sha256" {
+  ## Files ignored by `.gitignore` and `.dockerignore` as well as `dist/` are not hashed.
+  value = provider::gopackager::hash_dir("cmd/cli", ["dist/"])["sha256"]
+}
@@ -0,0 +1,4 @@
+# Example on how to hash a prebuilt artifact.
+output "artifact_sha256_base64" {
+  value = provider::gopackager::hash_file("dist/function.zip")["sha256_base64"]
+}
@@ -1,2 +1,24 @@
 provider "gopackager" {
+  # Optional
+  ## Default GOOS and GOARCH for all builds, which can be overridden per block.
+  goos   = "linux"
+  goarch = "arm64"
+  ## Path or name of the go binary.
+  go_binary = "/usr/local/go/bin/go"
+  ## Default additional flags passed to `go build`.
+  build_flags = ["-v"]
+  ## Default environment variables, which are merged with the ones of a block.
+  env = {
+    "GOARM64" = "v8.0"
+  }
+  ## Build cache directory (`GOCACHE`) shared by all builds.
+  build_cache_dir = ".cache/go-build"
+  ## Root directory for relative destinations.
+  artifact_dir = "build"
+  ## Number of source files hashed in parallel.
+  hash_concurrency = 8
+  ## Cache of source file digests shared by all runs.
+  hash_cache_dir = ".cache/gopackager-hashes"
+  ## Change the key to invalidate the cache once.
+  hash_cache_reset = "2024-01-31"
 }
@@ -0,0 +1,57 @@
+resource "gopackager_binary" "example" {
+  # Required
+  ## Path to the main GoLang source or the root path of this file.
+  source = "src/main.go"
+  ## Output destination file.
+  destination = "service/bootstrap"
+
+  # Optional
+  ## GOOS for compilation, defaults to the `goos` of the provider.
+  goos = "linux"
+  ## GOARCH for compilation, defaults to the `goarch` of the provider.
+  goarch = "amd64"
+  ## Archive the compiled binary and additional resources (`zip`, `tar.gz` or `tar.zst`).
+  archive_format = "zip"
+  ## Additional resources to be archived.
+  ## {source_path = destination_path}
+  zip_resources = {
+    "static"  = "www/static"
+    "LICENSE" = "LICENSE"
+  }
+  ## Overwrite the permissions of files inside of the archive.
+  ## {path_inside_archive = octal_permission}
+  zip_file_modes = {
+    "bootstrap" = "0755"
+  }
+  ## Base path to use for hash calculation.
+  base_path = "./src"
+  ## Only hash the files the binary is built from instead of the whole base path.
+  hash_mode = "deps"
+  ## Build tags passed to `go build -tags`.
+  tags = ["lambda.norpc"]
+  ## Set `CGO_ENABLED` explicitly instead of depending on the host environment.
+  cgo_enabled = false
+  ## Build byte-identical binaries for the same source on every machine.
+  reproducible = true
+  ## Additional flags passed to `go build`, replacing the `build_flags` of the provider.
+  build_flags = ["-v"]
+  ## Linker flags passed to `go build -ldflags`.
+  ldflags {
+    strip_symbols = true
+    strip_dwarf   = true
+  }
+}
+
+# The binary is only rebuilt if the source hash (`output_*`) or the configuration changes.
+# The hashes are computed at plan time, so a plan shows whether the binary will be rebuilt.
+# The artifact at `output_path` is deleted on destroy.
+resource "aws_lambda_function" "example" {
+  function_name    = "example"
+  runtime          = "provided.al2023"
+  handler          = "bootstrap"
+  role             = aws_iam_role.lambda_role.arn
+  timeout          = 15
+  filename         = gopackager_binary.example.output_path
+  source_code_hash = gopackager_binary.example.artifact_sha256_base64
+  memory_size      = 128
+}
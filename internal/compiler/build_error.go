@@ -0,0 +1,118 @@
+package compiler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// compileErrorPattern matches errors of the go toolchain like `./main.go:12:5: undefined: foo`.
+var compileErrorPattern = regexp.MustCompile(`^(.+\.(?:go|s|c|h|cc|cpp|m|S)):(\d+)(?::(\d+))?: (.+)$`)
+
+// CompileError is a single error of the build located in a source file.
+type CompileError struct {
+	// Package is the import path of the package, if reported by the toolchain.
+	Package string
+	// File is the absolute path of the source file.
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Location returns the location of the error (`file:line:col`).
+func (e CompileError) Location() string {
+	location := e.File + ":" + strconv.Itoa(e.Line)
+	if e.Column > 0 {
+		location += ":" + strconv.Itoa(e.Column)
+	}
+
+	return location
+}
+
+// String returns the error in the format of the toolchain (`file:line:col: message`).
+func (e CompileError) String() string {
+	return e.Location() + ": " + e.Message
+}
+
+// BuildError is the error returned if `go build` fails.
+// It contains the errors located in source files, which are empty if
+// the build failed for another reason (e.g. a module download).
+type BuildError struct {
+	Command string
+	Output  string
+	Errors  []CompileError
+	Err     error
+}
+
+// Error returns the error with the command and the complete output.
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("unable to compile binary: %s, \n\tcommand: %s, \n\toutput: %s", e.Err, e.Command, e.Output)
+}
+
+// Unwrap returns the error of the command.
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// buildEvent is an event of `go build -json`.
+type buildEvent struct {
+	ImportPath string `json:"ImportPath"`
+	Action     string `json:"Action"`
+	Output     string `json:"Output"`
+}
+
+// parseBuildOutput parses the output of `go build` or `go build -json` into the plain
+// text output and the errors located in source files. Relative paths are resolved against dir.
+func parseBuildOutput(output []byte, dir string) (string, []CompileError) {
+	text := strings.Builder{}
+	errs := []CompileError{}
+	pkg := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		event := buildEvent{}
+		if strings.HasPrefix(line, "{") && json.Unmarshal([]byte(line), &event) == nil {
+			if event.Action != "build-output" {
+				continue
+			}
+
+			pkg = event.ImportPath
+			line = strings.TrimSuffix(event.Output, "\n")
+		}
+
+		text.WriteString(line + "\n")
+
+		switch match := compileErrorPattern.FindStringSubmatch(line); {
+		case strings.HasPrefix(line, "# "):
+			pkg = strings.TrimPrefix(line, "# ")
+		case match != nil:
+			file := match[1]
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(dir, file)
+			}
+
+			lineNumber, _ := strconv.Atoi(match[2])
+			column, _ := strconv.Atoi(match[3])
+			errs = append(errs, CompileError{
+				Package: pkg,
+				File:    file,
+				Line:    lineNumber,
+				Column:  column,
+				Message: match[4],
+			})
+		case strings.HasPrefix(line, "\t") && len(errs) > 0:
+			// Details of the previous error (e.g. `have` and `want` of a type mismatch).
+			errs[len(errs)-1].Message += "\n" + line
+		}
+	}
+
+	return text.String(), errs
+}
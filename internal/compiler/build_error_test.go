@@ -0,0 +1,74 @@
+package compiler
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccParseBuildOutput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	t.Run("Text", func(t *testing.T) {
+		t.Parallel()
+
+		output := "go: downloading example.com/dep v1.0.0\n" +
+			"# example.com/app/sub\n" +
+			"sub/s.go:3:19: too many return values\n" +
+			"\thave (number)\n" +
+			"\twant ()\n" +
+			"# example.com/app\n" +
+			"./main.go:7:2: undefined: foo\n" +
+			"/abs/asm.s:4: unexpected EOF\n"
+
+		text, errs := parseBuildOutput([]byte(output), dir)
+		assert.Equal(t, output, text)
+		assert.Equal(t, []CompileError{
+			{Package: "example.com/app/sub", File: filepath.Join(dir, "sub", "s.go"), Line: 3, Column: 19, Message: "too many return values\n\thave (number)\n\twant ()"},
+			{Package: "example.com/app", File: filepath.Join(dir, "main.go"), Line: 7, Column: 2, Message: "undefined: foo"},
+			{Package: "example.com/app", File: "/abs/asm.s", Line: 4, Message: "unexpected EOF"},
+		}, errs)
+		assert.Equal(t, filepath.Join(dir, "main.go")+":7:2: undefined: foo", errs[1].String())
+		assert.Equal(t, "/abs/asm.s:4: unexpected EOF", errs[2].String())
+		assert.Equal(t, "/abs/asm.s:4", errs[2].Location())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		output := "go: downloading example.com/dep v1.0.0\n" +
+			`{"ImportPath":"example.com/app","Action":"build-output","Output":"# example.com/app\n"}` + "\n" +
+			`{"ImportPath":"example.com/app","Action":"build-output","Output":"./main.go:6:6: declared and not used: x\n"}` + "\n" +
+			`{"ImportPath":"example.com/app","Action":"build-output","Output":"./main.go:7:2: undefined: foo\n"}` + "\n" +
+			`{"ImportPath":"example.com/app","Action":"build-fail"}` + "\n"
+
+		text, errs := parseBuildOutput([]byte(output), dir)
+		assert.Equal(t, "go: downloading example.com/dep v1.0.0\n# example.com/app\n./main.go:6:6: declared and not used: x\n./main.go:7:2: undefined: foo\n", text)
+		assert.Equal(t, []CompileError{
+			{Package: "example.com/app", File: filepath.Join(dir, "main.go"), Line: 6, Column: 6, Message: "declared and not used: x"},
+			{Package: "example.com/app", File: filepath.Join(dir, "main.go"), Line: 7, Column: 2, Message: "undefined: foo"},
+		}, errs)
+	})
+
+	t.Run("NoCompileErrors", func(t *testing.T) {
+		t.Parallel()
+
+		output := "go: example.com/dep@v1.0.0: reading https://proxy.golang.org: 404 Not Found\n"
+
+		text, errs := parseBuildOutput([]byte(output), dir)
+		assert.Equal(t, output, text)
+		assert.Empty(t, errs)
+	})
+}
+
+func TestAccBuildError(t *testing.T) {
+	t.Parallel()
+
+	err := error(&BuildError{Command: "go build", Output: "output\n", Err: errors.New("exit status 1")})
+	assert.Equal(t, "unable to compile binary: exit status 1, \n\tcommand: go build, \n\toutput: output\n", err.Error())
+	assert.EqualError(t, errors.Unwrap(err), "exit status 1")
+}
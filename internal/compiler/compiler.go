@@ -1,20 +1,53 @@
 package compiler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 )
 
 // ErrUnableToGetWorkingDirectory is an error returned when the current working directory cannot be retrieved.
 var ErrUnableToGetWorkingDirectory = errors.New("unable to get current working directory")
 
+// ErrGoBinaryNotFound is an error returned when the go binary cannot be found.
+var ErrGoBinaryNotFound = errors.New("go binary not found")
+
+// ErrBuildTimedOut is an error returned when the build doesn't finish before the deadline of the context.
+var ErrBuildTimedOut = errors.New("build timed out")
+
+// ErrBuildCanceled is an error returned when the build is canceled via the context.
+var ErrBuildCanceled = errors.New("build canceled")
+
+// waitDelay is the time to wait for the output of killed processes to be closed.
+const waitDelay = 5 * time.Second
+
+// LookupGo resolves the go binary, which is either a path or a name looked up in the PATH.
+// It defaults to `go` if the given binary is empty.
+func LookupGo(goBinary string) (string, error) {
+	if goBinary == "" {
+		goBinary = "go"
+	}
+
+	executable, err := exec.LookPath(goBinary)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrGoBinaryNotFound, err)
+	}
+
+	return executable, nil
+}
+
 // CompilerI is an interface for the Compiler type.
 type CompilerI interface {
-	Compile(conf Config) (binaryLocation string, err error)
+	Compile(ctx context.Context, conf Config) (binaryLocation string, err error)
+	Ports(ctx context.Context, goBinary string) (Ports, error)
+	SourceFiles(ctx context.Context, conf Config) ([]string, error)
+	Toolchain(ctx context.Context, conf Config) (*Toolchain, error)
 }
 
 // Compiler is a type that implements the CompilerI interface.
@@ -28,8 +61,9 @@ func New() *Compiler {
 
 // Compile compiles the source code into a binary.
 // It takes a Config instance as parameter and Verify it beforehand.
+// The build process and its children are killed if the context is canceled.
 // It returns the binary location, the SHA256 hash of the binary and an error if any.
-func (c *Compiler) Compile(conf Config) (binaryLocation string, err error) {
+func (c *Compiler) Compile(ctx context.Context, conf Config) (binaryLocation string, err error) {
 	if err := conf.Verify(); err != nil {
 		return binaryLocation, err
 	} else if conf.source, err = filepath.Abs(conf.source); err != nil {
@@ -48,19 +82,39 @@ func (c *Compiler) Compile(conf Config) (binaryLocation string, err error) {
 		}
 	}
 
-	args := []string{"build", "-mod=mod", "-o", conf.destination}
+	executable, err := LookupGo(conf.goBinary)
+	if err != nil {
+		return "", err
+	}
+
+	environ := conf.environ(os.Environ())
+	args := conf.args()
+	if supportsBuildJSON(ctx, executable, environ) && !slices.Contains(args, "-json") {
+		// The errors are reported as JSON, which separates them from other output like module downloads.
+		args = slices.Insert(args, 1, "-json")
+	}
+
 	if strings.HasSuffix(conf.source, ".go") {
 		args = append(args, filepath.Base(conf.source))
 		conf.source = filepath.Dir(conf.source)
 	}
 
-	cmd := exec.Command("go", args...)
+	cmd := exec.CommandContext(ctx, executable, args...)
 	cmd.Dir = conf.source
-	cmd.Env = append(os.Environ(), "GOOS="+conf.goos, "GOARCH="+conf.goarch)
+	cmd.Env = environ
+	cmd.WaitDelay = waitDelay
+	setProcessGroup(cmd)
 	if combinedOutput, err := cmd.CombinedOutput(); err != nil {
-		return "", fmt.Errorf(
-			"unable to compile binary: %w, \n\tcommand: %s, \n\toutput: %s",
-			err, cmd.String(), string(combinedOutput))
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return "", fmt.Errorf("%w: \n\tcommand: %s", ErrBuildTimedOut, cmd.String())
+		case ctx.Err() != nil:
+			return "", fmt.Errorf("%w: \n\tcommand: %s", ErrBuildCanceled, cmd.String())
+		}
+
+		output, errs := parseBuildOutput(combinedOutput, conf.source)
+
+		return "", &BuildError{Command: cmd.String(), Output: output, Errors: errs, Err: err}
 	}
 
 	return conf.destination, nil
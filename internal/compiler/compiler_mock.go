@@ -1,6 +1,10 @@
 package compiler
 
-import "github.com/stretchr/testify/mock"
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
 
 // MockCompiler is an mock type for the Compiler type.
 type MockCompiler struct {
@@ -8,8 +12,40 @@ type MockCompiler struct {
 }
 
 // Compile is a mock implementation of the Compiler.Compile method.
-func (m *MockCompiler) Compile(conf Config) (string, error) {
-	ret := m.Called(conf)
+// The binary location can be returned as value or as function of the config.
+func (m *MockCompiler) Compile(ctx context.Context, conf Config) (string, error) {
+	ret := m.Called(ctx, conf)
+
+	if binaryLocation, ok := ret.Get(0).(func(Config) string); ok {
+		return binaryLocation(conf), ret.Error(1)
+	}
 
 	return ret.Get(0).(string), ret.Error(1) //nolint:forcetypeassert
 }
+
+// Ports is a mock implementation of the Compiler.Ports method.
+func (m *MockCompiler) Ports(ctx context.Context, goBinary string) (Ports, error) {
+	ret := m.Called(ctx, goBinary)
+
+	return ret.Get(0).(Ports), ret.Error(1) //nolint:forcetypeassert
+}
+
+// SourceFiles is a mock implementation of the Compiler.SourceFiles method.
+func (m *MockCompiler) SourceFiles(ctx context.Context, conf Config) ([]string, error) {
+	ret := m.Called(ctx, conf)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+
+	return ret.Get(0).([]string), ret.Error(1) //nolint:forcetypeassert
+}
+
+// Toolchain is a mock implementation of the Compiler.Toolchain method.
+func (m *MockCompiler) Toolchain(ctx context.Context, conf Config) (*Toolchain, error) {
+	ret := m.Called(ctx, conf)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+
+	return ret.Get(0).(*Toolchain), ret.Error(1) //nolint:forcetypeassert
+}
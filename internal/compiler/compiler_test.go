@@ -1,9 +1,15 @@
 package compiler
 
 import (
+	"bytes"
+	"context"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -34,7 +40,7 @@ func TestAccCompiler(t *testing.T) {
 		assert.NotNil(t, conf)
 
 		compiler := New()
-		binaryPath, err := compiler.Compile(*conf)
+		binaryPath, err := compiler.Compile(context.Background(), *conf)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, binaryPath)
 		assert.True(t, strings.HasSuffix(binaryPath, "binary"))
@@ -51,9 +57,259 @@ func TestAccCompiler(t *testing.T) {
 		assert.NotNil(t, conf)
 
 		compiler := New()
-		binaryPath, err := compiler.Compile(*conf)
+		binaryPath, err := compiler.Compile(context.Background(), *conf)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, binaryPath)
 		assert.True(t, strings.HasSuffix(binaryPath, "binary2"))
 	})
 }
+
+func TestAccCompilerLDFlags(t *testing.T) {
+	t.Parallel()
+
+	source := t.TempDir()
+	err := os.WriteFile(filepath.Join(source, "go.mod"), []byte("module example.com/ldflags\n\ngo 1.21\n"), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(source, "main.go"), []byte(`package main
+
+var version = "dev"
+
+func main() {
+	print(version)
+}
+`), 0644)
+	assert.NoError(t, err)
+
+	conf := NewConfig().
+		Source(source).
+		Destination(filepath.Join(t.TempDir(), "binary")).
+		GOOS(runtime.GOOS).
+		GOARCH(runtime.GOARCH).
+		LDFlags(LDFlags{
+			Variables:    map[string]string{"main.version": "v1.2.3 (test)"},
+			StripSymbols: true,
+			StripDWARF:   true,
+		})
+
+	binaryPath, err := New().Compile(context.Background(), *conf)
+	assert.NoError(t, err)
+
+	output, err := exec.Command(binaryPath).CombinedOutput()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.3 (test)", string(output))
+}
+
+func TestAccCompilerTags(t *testing.T) {
+	t.Parallel()
+
+	source := t.TempDir()
+	err := os.WriteFile(filepath.Join(source, "go.mod"), []byte("module example.com/tags\n\ngo 1.21\n"), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(source, "main.go"), []byte(`package main
+
+var variant = "default"
+
+func main() {
+	print(variant)
+}
+`), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(source, "custom.go"), []byte(`//go:build custom
+
+package main
+
+func init() {
+	variant = "custom"
+}
+`), 0644)
+	assert.NoError(t, err)
+
+	conf := NewConfig().
+		Source(source).
+		Destination(filepath.Join(t.TempDir(), "binary")).
+		GOOS(runtime.GOOS).
+		GOARCH(runtime.GOARCH).
+		Tags([]string{"custom"})
+
+	binaryPath, err := New().Compile(context.Background(), *conf)
+	assert.NoError(t, err)
+
+	output, err := exec.Command(binaryPath).CombinedOutput()
+	assert.NoError(t, err)
+	assert.Equal(t, "custom", string(output))
+}
+
+func TestAccCompilerReproducible(t *testing.T) {
+	t.Parallel()
+
+	// The same module is placed in two different directories,
+	// so the build must not depend on its location.
+	binaries := [][]byte{}
+	for range 2 {
+		source := t.TempDir()
+		err := os.WriteFile(filepath.Join(source, "go.mod"), []byte("module example.com/reproducible\n\ngo 1.21\n"), 0644)
+		assert.NoError(t, err)
+		err = os.WriteFile(filepath.Join(source, "main.go"), []byte(`package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("reproducible")
+}
+`), 0644)
+		assert.NoError(t, err)
+
+		conf := NewConfig().
+			Source(source).
+			Destination(filepath.Join(t.TempDir(), "binary")).
+			GOOS("linux").
+			GOARCH("amd64").
+			CGOEnabled(false).
+			Reproducible(true)
+
+		binaryPath, err := New().Compile(context.Background(), *conf)
+		assert.NoError(t, err)
+
+		binary, err := os.ReadFile(binaryPath)
+		assert.NoError(t, err)
+		binaries = append(binaries, binary)
+	}
+
+	assert.NotEmpty(t, binaries[0])
+	assert.True(t, bytes.Equal(binaries[0], binaries[1]), "expected byte-identical binaries")
+}
+
+func TestAccLookupGo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+
+		executable, err := LookupGo("")
+		assert.NoError(t, err)
+		assert.True(t, filepath.IsAbs(executable))
+	})
+
+	t.Run("Path", func(t *testing.T) {
+		t.Parallel()
+
+		expected, err := exec.LookPath("go")
+		assert.NoError(t, err)
+
+		executable, err := LookupGo(expected)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, executable)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := LookupGo(filepath.Join(t.TempDir(), "go"))
+		assert.ErrorIs(t, err, ErrGoBinaryNotFound)
+
+		_, err = New().Compile(context.Background(), *NewConfig().
+			Source("../../main.go").
+			Destination(filepath.Join(t.TempDir(), "binary")).
+			GOOS("linux").
+			GOARCH("amd64").
+			GoBinary("go-does-not-exist"))
+		assert.ErrorIs(t, err, ErrGoBinaryNotFound)
+	})
+}
+
+func TestAccCompilerCacheDir(t *testing.T) {
+	t.Parallel()
+
+	source := t.TempDir()
+	err := os.WriteFile(filepath.Join(source, "go.mod"), []byte("module example.com/cache\n\ngo 1.21\n"), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(source, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)
+	assert.NoError(t, err)
+
+	cacheDir := t.TempDir()
+	conf := NewConfig().
+		Source(source).
+		Destination(filepath.Join(t.TempDir(), "binary")).
+		GOOS(runtime.GOOS).
+		GOARCH(runtime.GOARCH).
+		CacheDir(cacheDir)
+
+	_, err = New().Compile(context.Background(), *conf)
+	assert.NoError(t, err)
+
+	entries, err := os.ReadDir(cacheDir)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, entries)
+}
+
+func TestAccCompilerContext(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("The fake go binary is a shell script.")
+	}
+
+	// The fake go binary starts a child process, which has to be killed together with it.
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+	goBinary := filepath.Join(t.TempDir(), "go")
+	err := os.WriteFile(goBinary, []byte("#!/bin/sh\n[ \"$1\" = env ] && echo go1.23.0 && exit 0\nsleep 60 &\necho $! > "+pidFile+"\nwait\n"), 0755)
+	assert.NoError(t, err)
+
+	conf := NewConfig().
+		Source(t.TempDir()).
+		Destination(filepath.Join(t.TempDir(), "binary")).
+		GOOS("linux").
+		GOARCH("amd64").
+		GoBinary(goBinary)
+
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := New().Compile(ctx, *conf)
+		assert.ErrorIs(t, err, ErrBuildTimedOut)
+		assert.Less(t, time.Since(start), waitDelay)
+
+		pid, err := os.ReadFile(pidFile)
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			return exec.Command("kill", "-0", strings.TrimSpace(string(pid))).Run() != nil
+		}, waitDelay, 50*time.Millisecond, "expected child process to be killed")
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(500*time.Millisecond, cancel)
+
+		_, err := New().Compile(ctx, *conf)
+		assert.ErrorIs(t, err, ErrBuildCanceled)
+	})
+}
+
+func TestAccCompilerBuildError(t *testing.T) {
+	t.Parallel()
+
+	source := t.TempDir()
+	err := os.WriteFile(filepath.Join(source, "go.mod"), []byte("module example.com/broken\n\ngo 1.21\n"), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(source, "main.go"), []byte("package main\n\nfunc main() {\n\tvar x int = \"a\"\n\tfoo()\n}\n"), 0644)
+	assert.NoError(t, err)
+
+	_, err = New().Compile(context.Background(), *NewConfig().
+		Source(source).
+		Destination(filepath.Join(t.TempDir(), "binary")).
+		GOOS(runtime.GOOS).
+		GOARCH(runtime.GOARCH))
+
+	buildErr := &BuildError{}
+	assert.ErrorAs(t, err, &buildErr)
+	assert.Len(t, buildErr.Errors, 3)
+	for _, compileErr := range buildErr.Errors {
+		assert.Equal(t, "example.com/broken", compileErr.Package)
+		assert.Equal(t, filepath.Join(source, "main.go"), compileErr.File)
+	}
+
+	assert.Contains(t, buildErr.Output, "undefined: foo")
+	assert.NotContains(t, buildErr.Output, `"Action"`)
+}
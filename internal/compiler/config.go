@@ -2,6 +2,9 @@ package compiler
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
 	"strings"
 )
 
@@ -14,14 +17,124 @@ var (
 	ErrGOOSNoSet = errors.New("GOOS not set")
 	// Error when the GOARCH is not set.
 	ErrGOARCHNoSet = errors.New("GOARCH not set")
+	// Error when a variant is set for a GOARCH without variants.
+	ErrVariantNotSupported = errors.New("variant not supported for GOARCH")
+	// ErrInvalidLDFlag is returned when a linker flag can not be passed to the go tool.
+	ErrInvalidLDFlag = errors.New("invalid linker flag")
 )
 
 // Configuration for the compiler.
 type Config struct {
-	source      string
-	destination string
-	goos        string
-	goarch      string
+	source       string
+	destination  string
+	goos         string
+	goarch       string
+	ldflags      LDFlags
+	tags         []string
+	cgoEnabled   *bool
+	env          map[string]string
+	reproducible bool
+	goBinary     string
+	buildFlags   []string
+	cacheDir     string
+	variant      string
+}
+
+// hostGoVariables are the GO* variables that are taken over from the host environment.
+// They describe where the toolchain finds or stores modules and caches and do not influence the binary.
+// Every other GO* variable must be declared explicitly via `Env`.
+var hostGoVariables = []string{
+	"GOPATH",
+	"GOROOT",
+	"GOCACHE",
+	"GOMODCACHE",
+	"GOTMPDIR",
+	"GOPROXY",
+	"GOPRIVATE",
+	"GONOPROXY",
+	"GONOSUMDB",
+	"GOSUMDB",
+	"GOINSECURE",
+	"GOAUTH",
+	"GOVCS",
+}
+
+// variantVariables are the environment variables selecting the variant of a GOARCH.
+var variantVariables = map[string]string{
+	"386":      "GO386",
+	"amd64":    "GOAMD64",
+	"arm":      "GOARM",
+	"arm64":    "GOARM64",
+	"mips":     "GOMIPS",
+	"mipsle":   "GOMIPS",
+	"mips64":   "GOMIPS64",
+	"mips64le": "GOMIPS64",
+	"ppc64":    "GOPPC64",
+	"ppc64le":  "GOPPC64",
+	"riscv64":  "GORISCV64",
+	"wasm":     "GOWASM",
+}
+
+// LDFlags describes the linker flags passed to `go build -ldflags`.
+type LDFlags struct {
+	// Variables are set via `-X importpath.name=value`.
+	Variables map[string]string
+	// StripSymbols omits the symbol table and debug information (`-s`).
+	StripSymbols bool
+	// StripDWARF omits the DWARF symbol table (`-w`).
+	StripDWARF bool
+}
+
+// String returns the linker flags as a single argument for `-ldflags`.
+// Variables are sorted by name to keep the argument deterministic.
+func (l LDFlags) String() string {
+	flags := []string{}
+	if l.StripSymbols {
+		flags = append(flags, "-s")
+	}
+
+	if l.StripDWARF {
+		flags = append(flags, "-w")
+	}
+
+	names := make([]string, 0, len(l.Variables))
+	for name := range l.Variables {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		flags = append(flags, "-X", quoteLDFlag(name+"="+l.Variables[name]))
+	}
+
+	return strings.Join(flags, " ")
+}
+
+// verify checks that all variables can be quoted by `quoteLDFlag`.
+func (l LDFlags) verify() error {
+	for name, value := range l.Variables {
+		if variable := name + "=" + value; strings.Contains(variable, "'") && strings.Contains(variable, `"`) {
+			return fmt.Errorf("%w: '%s' contains both single and double quotes", ErrInvalidLDFlag, name)
+		}
+	}
+
+	return nil
+}
+
+// quoteLDFlag quotes a linker flag if it contains whitespace, as the go tool
+// splits the `-ldflags` argument into fields. Quotes can not be escaped,
+// therefore the quote character not used by the value is chosen.
+// Flags with both quote characters are rejected by `Verify`.
+func quoteLDFlag(value string) string {
+	switch {
+	case !strings.ContainsAny(value, " \t\n\r'\""):
+		return value
+	case !strings.Contains(value, "'"):
+		return "'" + value + "'"
+	default:
+		return `"` + value + `"`
+	}
 }
 
 // NewConfig creates a new config.
@@ -57,6 +170,72 @@ func (c *Config) GOARCH(goarch string) *Config {
 	return c
 }
 
+// Set the linker flags.
+func (c *Config) LDFlags(ldflags LDFlags) *Config {
+	c.ldflags = ldflags
+
+	return c
+}
+
+// Set the build tags.
+func (c *Config) Tags(tags []string) *Config {
+	c.tags = tags
+
+	return c
+}
+
+// Set whether cgo is enabled (`CGO_ENABLED`).
+func (c *Config) CGOEnabled(enabled bool) *Config {
+	c.cgoEnabled = &enabled
+
+	return c
+}
+
+// Set additional environment variables for the build.
+func (c *Config) Env(env map[string]string) *Config {
+	c.env = env
+
+	return c
+}
+
+// Set the reproducible mode.
+// The build then strips file system paths, VCS information and the build ID and ignores
+// the `GOFLAGS` and go env file of the host, so the same source results in the same binary.
+func (c *Config) Reproducible(reproducible bool) *Config {
+	c.reproducible = reproducible
+
+	return c
+}
+
+// Set the go binary, which is either a path or a name looked up in the PATH.
+func (c *Config) GoBinary(goBinary string) *Config {
+	c.goBinary = goBinary
+
+	return c
+}
+
+// Set additional flags passed to `go build` (e.g. `-race`).
+func (c *Config) BuildFlags(flags []string) *Config {
+	c.buildFlags = flags
+
+	return c
+}
+
+// Set the build cache directory (`GOCACHE`).
+func (c *Config) CacheDir(path string) *Config {
+	c.cacheDir = path
+
+	return c
+}
+
+// Set the variant of the GOARCH (e.g. `v3` for `amd64` or `7` for `arm`),
+// which is passed as the matching variable like `GOAMD64` or `GOARM`.
+func (c *Config) Variant(variant string) *Config {
+	c.variant = variant
+
+	return c
+}
+
 // Verifies the config.
 func (c *Config) Verify() error {
 	switch {
@@ -68,9 +247,147 @@ func (c *Config) Verify() error {
 		return ErrGOOSNoSet
 	case c.goarch == "":
 		return ErrGOARCHNoSet
+	case c.variant != "" && variantVariables[c.goarch] == "":
+		return fmt.Errorf("%w: %s", ErrVariantNotSupported, c.goarch)
 	}
 
-	return nil
+	return c.ldflags.verify()
+}
+
+// Fingerprint returns a deterministic representation of the build settings
+// that are not part of the source files but change the resulting binary.
+// It is empty if no such setting is configured.
+func (c *Config) Fingerprint() string {
+	parts := []string{}
+	if tags := c.sortedTags(); len(tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(tags, ","))
+	}
+
+	if c.cgoEnabled != nil {
+		parts = append(parts, fmt.Sprintf("cgo=%t", *c.cgoEnabled))
+	}
+
+	for _, variable := range c.sortedEnv() {
+		parts = append(parts, "env="+variable)
+	}
+
+	if c.reproducible {
+		parts = append(parts, "reproducible=true")
+	}
+
+	if len(c.buildFlags) > 0 {
+		parts = append(parts, "flags="+strings.Join(c.buildFlags, " "))
+	}
+
+	if ldflags := c.ldflags.String(); ldflags != "" {
+		parts = append(parts, "ldflags="+ldflags)
+	}
+
+	if c.variant != "" {
+		parts = append(parts, "variant="+c.variant)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// environ builds the environment for the build based on the given host environment.
+// GO* variables and `CGO_ENABLED` of the host are dropped unless listed in `hostGoVariables`,
+// so the result does not depend on the machine running the build.
+func (c *Config) environ(host []string) []string {
+	environ := []string{}
+	for _, variable := range host {
+		name, _, _ := strings.Cut(variable, "=")
+		if (strings.HasPrefix(name, "GO") || name == "CGO_ENABLED") && !slices.Contains(hostGoVariables, name) {
+			continue
+		} else if name == "GOCACHE" && c.cacheDir != "" {
+			continue
+		}
+
+		environ = append(environ, variable)
+	}
+
+	if c.cacheDir != "" {
+		environ = append(environ, "GOCACHE="+c.cacheDir)
+	}
+
+	environ = append(environ, c.sortedEnv()...)
+	environ = append(environ, "GOOS="+c.goos, "GOARCH="+c.goarch)
+	if variable := variantVariables[c.goarch]; variable != "" && c.variant != "" {
+		environ = append(environ, variable+"="+c.variant)
+	}
+	if c.cgoEnabled != nil {
+		cgoEnabled := "0"
+		if *c.cgoEnabled {
+			cgoEnabled = "1"
+		}
+
+		environ = append(environ, "CGO_ENABLED="+cgoEnabled)
+	}
+
+	if c.reproducible {
+		for _, variable := range []string{"GOFLAGS=", "GOENV=off"} {
+			name, _, _ := strings.Cut(variable, "=")
+			if _, declared := c.env[name]; !declared {
+				environ = append(environ, variable)
+			}
+		}
+	}
+
+	return environ
+}
+
+// args builds the arguments for `go build`.
+func (c *Config) args() []string {
+	args := []string{"build", "-mod=mod", "-o", c.destination}
+	if c.reproducible {
+		args = append(args, "-trimpath", "-buildvcs=false")
+	}
+
+	ldflags := c.ldflags.String()
+	if c.reproducible {
+		ldflags = strings.TrimSpace(ldflags + " -buildid=")
+	}
+
+	if ldflags != "" {
+		args = append(args, "-ldflags="+ldflags)
+	}
+
+	if tags := c.sortedTags(); len(tags) > 0 {
+		args = append(args, "-tags="+strings.Join(tags, ","))
+	}
+
+	return append(args, c.buildFlags...)
+}
+
+// sortedEnv returns the additional environment variables as `NAME=value` sorted by name.
+func (c *Config) sortedEnv() []string {
+	names := make([]string, 0, len(c.env))
+	for name := range c.env {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	env := make([]string, 0, len(names))
+	for _, name := range names {
+		env = append(env, name+"="+c.env[name])
+	}
+
+	return env
+}
+
+// sortedTags returns the build tags sorted and without duplicates.
+func (c *Config) sortedTags() []string {
+	tags := []string{}
+	for _, tag := range c.tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Strings(tags)
+
+	return tags
 }
 
 // Get the `Source` value.
@@ -92,3 +409,48 @@ func (c *Config) GetGOOS() string {
 func (c *Config) GetGOARCH() string {
 	return c.goarch
 }
+
+// Get the `LDFlags` value.
+func (c *Config) GetLDFlags() LDFlags {
+	return c.ldflags
+}
+
+// Get the `Tags` value.
+func (c *Config) GetTags() []string {
+	return c.tags
+}
+
+// Get the `CGOEnabled` value, nil if not set.
+func (c *Config) GetCGOEnabled() *bool {
+	return c.cgoEnabled
+}
+
+// Get the `Env` value.
+func (c *Config) GetEnv() map[string]string {
+	return c.env
+}
+
+// Get the `Reproducible` value.
+func (c *Config) GetReproducible() bool {
+	return c.reproducible
+}
+
+// Get the `GoBinary` value.
+func (c *Config) GetGoBinary() string {
+	return c.goBinary
+}
+
+// Get the `BuildFlags` value.
+func (c *Config) GetBuildFlags() []string {
+	return c.buildFlags
+}
+
+// Get the `CacheDir` value.
+func (c *Config) GetCacheDir() string {
+	return c.cacheDir
+}
+
+// Get the `Variant` value.
+func (c *Config) GetVariant() string {
+	return c.variant
+}
@@ -1,6 +1,8 @@
 package compiler
 
 import (
+	"os/exec"
+	"regexp"
 	"runtime"
 	"strings"
 	"testing"
@@ -11,11 +13,25 @@ import (
 func TestAccConfig(t *testing.T) {
 	t.Parallel()
 
+	cgoEnabled := false
 	expected := Config{
 		source:      "main.go",
 		destination: "binary",
 		goos:        "linux",
 		goarch:      "amd64",
+		ldflags: LDFlags{
+			Variables:    map[string]string{"main.version": "v1.0.0"},
+			StripSymbols: true,
+			StripDWARF:   true,
+		},
+		tags:         []string{"lambda.norpc", "netgo"},
+		cgoEnabled:   &cgoEnabled,
+		env:          map[string]string{"GOAMD64": "v3"},
+		reproducible: true,
+		goBinary:     "/usr/local/go/bin/go",
+		buildFlags:   []string{"-v"},
+		cacheDir:     "/tmp/gocache",
+		variant:      "v3",
 	}
 
 	actual := NewConfig()
@@ -33,6 +49,33 @@ func TestAccConfig(t *testing.T) {
 	actual = actual.GOARCH(expected.goarch)
 	assert.NotNil(t, actual)
 
+	actual = actual.LDFlags(expected.ldflags)
+	assert.NotNil(t, actual)
+
+	actual = actual.Tags(expected.tags)
+	assert.NotNil(t, actual)
+
+	actual = actual.CGOEnabled(*expected.cgoEnabled)
+	assert.NotNil(t, actual)
+
+	actual = actual.Env(expected.env)
+	assert.NotNil(t, actual)
+
+	actual = actual.Reproducible(expected.reproducible)
+	assert.NotNil(t, actual)
+
+	actual = actual.GoBinary(expected.goBinary)
+	assert.NotNil(t, actual)
+
+	actual = actual.BuildFlags(expected.buildFlags)
+	assert.NotNil(t, actual)
+
+	actual = actual.CacheDir(expected.cacheDir)
+	assert.NotNil(t, actual)
+
+	actual = actual.Variant(expected.variant)
+	assert.NotNil(t, actual)
+
 	assert.NotNil(t, actual)
 	assert.Equal(t, expected, *actual)
 
@@ -40,6 +83,294 @@ func TestAccConfig(t *testing.T) {
 	assert.Equal(t, expected.destination, actual.GetDestination())
 	assert.Equal(t, expected.goos, actual.GetGOOS())
 	assert.Equal(t, expected.goarch, actual.GetGOARCH())
+	assert.Equal(t, expected.ldflags, actual.GetLDFlags())
+	assert.Equal(t, expected.tags, actual.GetTags())
+	assert.Equal(t, expected.cgoEnabled, actual.GetCGOEnabled())
+	assert.Equal(t, expected.env, actual.GetEnv())
+	assert.Equal(t, expected.reproducible, actual.GetReproducible())
+	assert.Equal(t, expected.goBinary, actual.GetGoBinary())
+	assert.Equal(t, expected.buildFlags, actual.GetBuildFlags())
+	assert.Equal(t, expected.cacheDir, actual.GetCacheDir())
+	assert.Equal(t, expected.variant, actual.GetVariant())
+}
+
+func TestAccConfigArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().Destination("binary")
+		assert.Equal(t, []string{"build", "-mod=mod", "-o", "binary"}, c.args())
+	})
+
+	t.Run("Reproducible", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().
+			Destination("binary").
+			Tags([]string{"netgo"}).
+			LDFlags(LDFlags{StripSymbols: true}).
+			Reproducible(true)
+		assert.Equal(t, []string{
+			"build", "-mod=mod", "-o", "binary",
+			"-trimpath", "-buildvcs=false",
+			"-ldflags=-s -buildid=",
+			"-tags=netgo",
+		}, c.args())
+	})
+
+	t.Run("BuildFlags", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().
+			Destination("binary").
+			Tags([]string{"netgo"}).
+			BuildFlags([]string{"-v", "-race"})
+		assert.Equal(t, []string{"build", "-mod=mod", "-o", "binary", "-tags=netgo", "-v", "-race"}, c.args())
+	})
+}
+
+func TestAccConfigEnviron(t *testing.T) {
+	t.Parallel()
+
+	host := []string{
+		"HOME=/home/user",
+		"PATH=/usr/bin",
+		"GOPATH=/home/user/go",
+		"GOPROXY=https://proxy.golang.org",
+		"GOFLAGS=-race",
+		"GOAMD64=v4",
+		"GOOS=darwin",
+		"CGO_ENABLED=1",
+		"CGO_CFLAGS=-O2",
+		"GONOSUMCHECK=1",
+	}
+
+	t.Run("Host_Variables_Documented", func(t *testing.T) {
+		t.Parallel()
+
+		help, err := exec.Command("go", "help", "environment").Output()
+		assert.NoError(t, err)
+		for _, name := range hostGoVariables {
+			assert.Regexp(t, regexp.MustCompile(`\b`+name+`\b`), string(help), "unknown go variable")
+		}
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().GOOS("linux").GOARCH("amd64")
+		assert.Equal(t, []string{
+			"HOME=/home/user",
+			"PATH=/usr/bin",
+			"GOPATH=/home/user/go",
+			"GOPROXY=https://proxy.golang.org",
+			"CGO_CFLAGS=-O2",
+			"GOOS=linux",
+			"GOARCH=amd64",
+		}, c.environ(host))
+	})
+
+	t.Run("Declared", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().
+			GOOS("linux").
+			GOARCH("amd64").
+			CGOEnabled(false).
+			Env(map[string]string{
+				"GOAMD64": "v3",
+				"GOOS":    "windows",
+				"FOO":     "bar",
+			})
+		assert.Equal(t, []string{
+			"HOME=/home/user",
+			"PATH=/usr/bin",
+			"GOPATH=/home/user/go",
+			"GOPROXY=https://proxy.golang.org",
+			"CGO_CFLAGS=-O2",
+			"FOO=bar",
+			"GOAMD64=v3",
+			"GOOS=windows",
+			"GOOS=linux",
+			"GOARCH=amd64",
+			"CGO_ENABLED=0",
+		}, c.environ(host))
+	})
+
+	t.Run("Reproducible", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().
+			GOOS("linux").
+			GOARCH("amd64").
+			Env(map[string]string{"GOFLAGS": "-mod=vendor"}).
+			Reproducible(true)
+		assert.Equal(t, []string{
+			"HOME=/home/user",
+			"PATH=/usr/bin",
+			"GOPATH=/home/user/go",
+			"GOPROXY=https://proxy.golang.org",
+			"CGO_CFLAGS=-O2",
+			"GOFLAGS=-mod=vendor",
+			"GOOS=linux",
+			"GOARCH=amd64",
+			"GOENV=off",
+		}, c.environ(host))
+	})
+
+	t.Run("CacheDir", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().
+			GOOS("linux").
+			GOARCH("amd64").
+			CacheDir("/tmp/gocache")
+		assert.Equal(t, []string{
+			"HOME=/home/user",
+			"PATH=/usr/bin",
+			"GOPATH=/home/user/go",
+			"GOPROXY=https://proxy.golang.org",
+			"CGO_CFLAGS=-O2",
+			"GOCACHE=/tmp/gocache",
+			"GOOS=linux",
+			"GOARCH=amd64",
+		}, c.environ(append(host, "GOCACHE=/home/user/.cache/go-build")))
+	})
+
+	t.Run("Variant", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().
+			GOOS("linux").
+			GOARCH("arm").
+			Env(map[string]string{"GOARM": "6"}).
+			Variant("7")
+		assert.Equal(t, []string{
+			"HOME=/home/user",
+			"PATH=/usr/bin",
+			"GOPATH=/home/user/go",
+			"GOPROXY=https://proxy.golang.org",
+			"CGO_CFLAGS=-O2",
+			"GOARM=6",
+			"GOOS=linux",
+			"GOARCH=arm",
+			"GOARM=7",
+		}, c.environ(host))
+	})
+}
+
+func TestAccConfigFingerprint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "", NewConfig().Fingerprint())
+		assert.Equal(t, "", NewConfig().Tags([]string{" ", ""}).Fingerprint())
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewConfig().Tags([]string{"netgo", "lambda.norpc", "netgo"})
+		b := NewConfig().Tags([]string{"lambda.norpc", "netgo"})
+		assert.Equal(t, "tags=lambda.norpc,netgo", a.Fingerprint())
+		assert.Equal(t, a.Fingerprint(), b.Fingerprint())
+		assert.NotEqual(t, a.Fingerprint(), NewConfig().Tags([]string{"netgo"}).Fingerprint())
+	})
+
+	t.Run("CGOEnabled", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "cgo=false", NewConfig().CGOEnabled(false).Fingerprint())
+		assert.Equal(t, "cgo=true", NewConfig().CGOEnabled(true).Fingerprint())
+	})
+
+	t.Run("Env", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().
+			Tags([]string{"netgo"}).
+			Env(map[string]string{"GOAMD64": "v3", "GOARM": "7"})
+		assert.Equal(t, "tags=netgo\nenv=GOAMD64=v3\nenv=GOARM=7", c.Fingerprint())
+	})
+
+	t.Run("Reproducible", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "reproducible=true", NewConfig().Reproducible(true).Fingerprint())
+	})
+
+	t.Run("BuildFlags", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "flags=-race -v", NewConfig().BuildFlags([]string{"-race", "-v"}).Fingerprint())
+		assert.Equal(t, "", NewConfig().GoBinary("go").CacheDir("/tmp/gocache").Fingerprint())
+	})
+
+	t.Run("Variant", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "variant=v3", NewConfig().GOARCH("amd64").Variant("v3").Fingerprint())
+	})
+
+	t.Run("LDFlags", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().LDFlags(LDFlags{
+			Variables:    map[string]string{"main.version": "v1.0.0", "main.commit": "abc123"},
+			StripSymbols: true,
+		})
+		assert.Equal(t, "ldflags=-s -X main.commit=abc123 -X main.version=v1.0.0", c.Fingerprint())
+		assert.NotEqual(t, c.Fingerprint(), NewConfig().LDFlags(LDFlags{
+			Variables:    map[string]string{"main.version": "v1.0.1", "main.commit": "abc123"},
+			StripSymbols: true,
+		}).Fingerprint())
+		assert.Equal(t, "", NewConfig().LDFlags(LDFlags{Variables: map[string]string{}}).Fingerprint())
+	})
+}
+
+func TestAccLDFlags(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "", LDFlags{}.String())
+	})
+
+	t.Run("Strip", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "-s -w", LDFlags{StripSymbols: true, StripDWARF: true}.String())
+	})
+
+	t.Run("Variables_Sorted", func(t *testing.T) {
+		t.Parallel()
+
+		ldflags := LDFlags{
+			Variables: map[string]string{
+				"main.version": "v1.0.0",
+				"main.commit":  "abc123",
+			},
+			StripDWARF: true,
+		}
+		assert.Equal(t, "-w -X main.commit=abc123 -X main.version=v1.0.0", ldflags.String())
+	})
+
+	t.Run("Variables_Quoted", func(t *testing.T) {
+		t.Parallel()
+
+		ldflags := LDFlags{
+			Variables: map[string]string{
+				"main.date":    "2024-01-01 12:00:00",
+				"main.message": "it's here",
+			},
+		}
+		assert.Equal(t, `-X 'main.date=2024-01-01 12:00:00' -X "main.message=it's here"`, ldflags.String())
+	})
 }
 
 func TestAccConfigVerify(t *testing.T) {
@@ -122,4 +453,37 @@ func TestAccConfigVerify(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.Equal(t, ErrGOARCHNoSet, err)
 	})
+
+	t.Run("VariantNotSupported", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().
+			Source(mainFile).
+			Destination("binary").
+			GOOS("linux").
+			GOARCH("s390x").
+			Variant("v1")
+
+		err := c.Verify()
+		assert.ErrorIs(t, err, ErrVariantNotSupported)
+		assert.NoError(t, c.GOARCH("amd64").Verify())
+	})
+
+	t.Run("InvalidLDFlag", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig().
+			Source(mainFile).
+			Destination("binary").
+			GOOS("linux").
+			GOARCH("amd64").
+			LDFlags(LDFlags{Variables: map[string]string{"main.message": `it's "here"`}})
+
+		err := c.Verify()
+		assert.ErrorIs(t, err, ErrInvalidLDFlag)
+		assert.ErrorContains(t, err, "'main.message'")
+
+		c.LDFlags(LDFlags{Variables: map[string]string{"main.message": `it's here`, "main.quoted": `"here"`}})
+		assert.NoError(t, c.Verify())
+	})
 }
@@ -0,0 +1,186 @@
+package compiler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// listFields are the fields of `go list -json` needed to collect the source files.
+var listFields = []string{
+	"ImportPath", "Dir", "Module",
+	"GoFiles", "CgoFiles", "CFiles", "CXXFiles", "MFiles", "HFiles", "FFiles", "SFiles", "SwigFiles", "SwigCXXFiles", "SysoFiles",
+	"EmbedFiles",
+}
+
+// commandLinePackage is the import path of a package given as list of files (e.g. `main.go`).
+const commandLinePackage = "command-line-arguments"
+
+// listModule is the module of a package reported by `go list -json`.
+type listModule struct {
+	Path    string      `json:"Path"`
+	Main    bool        `json:"Main"`
+	Dir     string      `json:"Dir"`
+	GoMod   string      `json:"GoMod"`
+	Version string      `json:"Version"`
+	Replace *listModule `json:"Replace"`
+}
+
+// listPackage is a package reported by `go list -json`.
+type listPackage struct {
+	ImportPath   string      `json:"ImportPath"`
+	Dir          string      `json:"Dir"`
+	Module       *listModule `json:"Module"`
+	GoFiles      []string    `json:"GoFiles"`
+	CgoFiles     []string    `json:"CgoFiles"`
+	CFiles       []string    `json:"CFiles"`
+	CXXFiles     []string    `json:"CXXFiles"`
+	MFiles       []string    `json:"MFiles"`
+	HFiles       []string    `json:"HFiles"`
+	FFiles       []string    `json:"FFiles"`
+	SFiles       []string    `json:"SFiles"`
+	SwigFiles    []string    `json:"SwigFiles"`
+	SwigCXXFiles []string    `json:"SwigCXXFiles"`
+	SysoFiles    []string    `json:"SysoFiles"`
+	EmbedFiles   []string    `json:"EmbedFiles"`
+}
+
+// local reports if the package is part of the main module or of a module replaced by a local directory.
+// Other modules are pinned by `go.sum` and the standard library by the toolchain.
+func (p listPackage) local() bool {
+	if p.ImportPath == commandLinePackage {
+		return true
+	} else if p.Module == nil {
+		return false
+	}
+
+	return p.Module.Main || (p.Module.Replace != nil && p.Module.Replace.Version == "")
+}
+
+// files returns the absolute paths of all files of the package used by the build.
+func (p listPackage) files() []string {
+	files := []string{}
+	for _, names := range [][]string{
+		p.GoFiles, p.CgoFiles, p.CFiles, p.CXXFiles, p.MFiles, p.HFiles, p.FFiles, p.SFiles, p.SwigFiles, p.SwigCXXFiles, p.SysoFiles,
+		p.EmbedFiles,
+	} {
+		for _, name := range names {
+			files = append(files, filepath.Join(p.Dir, name))
+		}
+	}
+
+	return files
+}
+
+// SourceFiles returns the sorted absolute paths of the files the binary is built from (`go list -deps -json`).
+// These are the files of all packages of the main module and of modules replaced by a local directory,
+// including embedded files, as well as their `go.mod` and `go.sum`.
+// Packages of other modules are covered by `go.sum` and the standard library by the toolchain.
+// The packages are resolved for the GOOS, GOARCH, tags and environment of the configuration.
+func (c *Compiler) SourceFiles(ctx context.Context, conf Config) ([]string, error) {
+	if conf.source == "" {
+		return nil, ErrSourceNotSet
+	} else if source, err := filepath.Abs(conf.source); err != nil {
+		return nil, fmt.Errorf("unable to get absolute path of source: %w", err)
+	} else {
+		conf.source = source
+	}
+
+	executable, err := LookupGo(conf.goBinary)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"list", "-mod=mod", "-deps", "-json=" + strings.Join(listFields, ",")}
+	if tags := conf.sortedTags(); len(tags) > 0 {
+		args = append(args, "-tags="+strings.Join(tags, ","))
+	}
+
+	pattern := "."
+	if strings.HasSuffix(conf.source, ".go") {
+		pattern = filepath.Base(conf.source)
+		conf.source = filepath.Dir(conf.source)
+	}
+
+	cmd := exec.CommandContext(ctx, executable, append(args, pattern)...)
+	cmd.Dir = conf.source
+	cmd.Env = conf.environ(os.Environ())
+	cmd.WaitDelay = waitDelay
+	setProcessGroup(cmd)
+
+	stderr := strings.Builder{}
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list dependencies: %w, \n\tcommand: %s, \n\toutput: %s", err, cmd.String(), stderr.String())
+	}
+
+	files := []string{}
+	decoder := json.NewDecoder(strings.NewReader(string(output)))
+	for {
+		pkg := listPackage{}
+		if err := decoder.Decode(&pkg); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to parse dependencies: %w", err)
+		}
+
+		if !pkg.local() {
+			continue
+		}
+
+		files = append(files, pkg.files()...)
+
+		var goMod string
+		switch module := pkg.Module; {
+		case module == nil:
+			goMod = findGoMod(pkg.Dir)
+		case module.Replace != nil:
+			goMod = module.Replace.GoMod
+		default:
+			goMod = module.GoMod
+		}
+
+		if goMod != "" {
+			files = append(files, goMod)
+			if goSum := filepath.Join(filepath.Dir(goMod), "go.sum"); fileExists(goSum) {
+				files = append(files, goSum)
+			}
+		}
+	}
+
+	slices.Sort(files)
+
+	return slices.Compact(files), nil
+}
+
+// findGoMod returns the `go.mod` of the module containing the directory or an empty string if there is none.
+// It is used for packages given as list of files, which are not reported with their module.
+func findGoMod(dir string) string {
+	for {
+		if goMod := filepath.Join(dir, "go.mod"); fileExists(goMod) {
+			return goMod
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
+
+// fileExists reports if the path exists and is a regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.Mode().IsRegular()
+}
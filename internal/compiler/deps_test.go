@@ -0,0 +1,99 @@
+package compiler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSourceFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"app/go.mod":           "module example.com/app\n\ngo 1.21\n\nrequire example.com/shared v0.0.0\n\nreplace example.com/shared => ../shared\n",
+		"app/go.sum":           "",
+		"app/README.md":        "readme",
+		"app/main.go":          "package main\n\nimport (\n\t\"example.com/app/sub\"\n\t\"example.com/shared\"\n)\n\nfunc main() {\n\tsub.F()\n\tprintln(shared.Data)\n}\n",
+		"app/sub/sub.go":       "package sub\n\nfunc F() {}\n",
+		"app/sub/sub_test.go":  "package sub\n",
+		"app/sub/windows.go":   "//go:build windows\n\npackage sub\n",
+		"app/sub/custom.go":    "//go:build custom\n\npackage sub\n",
+		"app/other/other.go":   "package other\n",
+		"shared/go.mod":        "module example.com/shared\n\ngo 1.21\n",
+		"shared/shared.go":     "package shared\n\nimport _ \"embed\"\n\n//go:embed data.txt\nvar Data string\n",
+		"shared/data.txt":      "data",
+		"shared/unused/not.go": "package unused\n",
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+	}
+
+	abs := func(paths ...string) []string {
+		for i, path := range paths {
+			paths[i] = filepath.Join(dir, path)
+		}
+
+		return paths
+	}
+
+	conf := NewConfig().
+		Source(filepath.Join(dir, "app")).
+		GOOS("linux").
+		GOARCH("amd64")
+
+	t.Run("Directory", func(t *testing.T) {
+		t.Parallel()
+
+		files, err := New().SourceFiles(context.Background(), *conf)
+		assert.NoError(t, err)
+		assert.Equal(t, abs(
+			"app/go.mod",
+			"app/go.sum",
+			"app/main.go",
+			"app/sub/sub.go",
+			"shared/data.txt",
+			"shared/go.mod",
+			"shared/shared.go",
+		), files)
+	})
+
+	t.Run("File_Tags_GOOS", func(t *testing.T) {
+		t.Parallel()
+
+		conf := *conf
+		files, err := New().SourceFiles(context.Background(), *conf.
+			Source(filepath.Join(dir, "app", "main.go")).
+			GOOS("windows").
+			Tags([]string{"custom"}))
+		assert.NoError(t, err)
+		assert.Equal(t, abs(
+			"app/go.mod",
+			"app/go.sum",
+			"app/main.go",
+			"app/sub/custom.go",
+			"app/sub/sub.go",
+			"app/sub/windows.go",
+			"shared/data.txt",
+			"shared/go.mod",
+			"shared/shared.go",
+		), files)
+	})
+
+	t.Run("SourceNotSet", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New().SourceFiles(context.Background(), *NewConfig())
+		assert.ErrorIs(t, err, ErrSourceNotSet)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New().SourceFiles(context.Background(), *NewConfig().Source(filepath.Join(dir, "shared", "missing")))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,78 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+var (
+	// ErrModuleNotFound is returned when no `go.mod` is found in the directory or its parents.
+	ErrModuleNotFound = errors.New("go.mod not found")
+	// ErrInvalidModule is returned when the `go.mod` can't be parsed.
+	ErrInvalidModule = errors.New("invalid go.mod")
+)
+
+// ReadModule parses the `go.mod` at the path, which can also be a directory of the module.
+// The absolute path of the parsed `go.mod` is `Syntax.Name` of the returned file.
+func ReadModule(path string) (*modfile.File, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	goMod := absPath
+	if info, err := os.Stat(absPath); err == nil && info.IsDir() {
+		goMod = findGoMod(absPath)
+	}
+
+	if goMod == "" {
+		return nil, fmt.Errorf("%w: '%s'", ErrModuleNotFound, path)
+	}
+
+	content, err := os.ReadFile(goMod)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: '%s'", ErrModuleNotFound, path)
+	} else if err != nil {
+		return nil, err
+	}
+
+	file, err := modfile.Parse(goMod, content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidModule, err)
+	}
+
+	if file.Module == nil {
+		return nil, fmt.Errorf("%w: '%s' has no module directive", ErrInvalidModule, goMod)
+	}
+
+	return file, nil
+}
+
+// ModulePath returns the path of the module containing the directory as declared in its `go.mod`.
+func ModulePath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	goMod := findGoMod(absDir)
+	if goMod == "" {
+		return "", fmt.Errorf("%w: '%s'", ErrModuleNotFound, dir)
+	}
+
+	content, err := os.ReadFile(goMod)
+	if err != nil {
+		return "", err
+	}
+
+	modulePath := modfile.ModulePath(content)
+	if modulePath == "" {
+		return "", fmt.Errorf("%w: '%s' has no module directive", ErrInvalidModule, goMod)
+	}
+
+	return modulePath, nil
+}
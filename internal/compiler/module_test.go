@@ -0,0 +1,113 @@
+package compiler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccModulePath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "app", "cmd", "cli"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "broken"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "app", "go.mod"), []byte("module \"example.com/app\"\n\ngo 1.21\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken", "go.mod"), []byte("go 1.21\n"), 0644))
+
+	t.Run("Module_Root", func(t *testing.T) {
+		t.Parallel()
+
+		modulePath, err := ModulePath(filepath.Join(dir, "app"))
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com/app", modulePath)
+	})
+
+	t.Run("Sub_Directory", func(t *testing.T) {
+		t.Parallel()
+
+		modulePath, err := ModulePath(filepath.Join(dir, "app", "cmd", "cli"))
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com/app", modulePath)
+	})
+
+	t.Run("No_Module_Directive", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ModulePath(filepath.Join(dir, "broken"))
+		assert.ErrorIs(t, err, ErrInvalidModule)
+	})
+
+	t.Run("Not_Found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ModulePath(dir)
+		assert.ErrorIs(t, err, ErrModuleNotFound)
+	})
+}
+
+func TestAccReadModule(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	goMod := filepath.Join(dir, "go.mod")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "cmd"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "invalid"), 0755))
+	assert.NoError(t, os.WriteFile(goMod, []byte(`module example.com/app
+
+go 1.24.0
+
+toolchain go1.25.1
+
+require (
+	example.com/direct v1.2.3
+	example.com/indirect v0.1.0 // indirect
+)
+
+replace example.com/direct v1.2.3 => ../direct
+
+retract [v1.0.0, v1.0.5] // Broken build.
+`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "invalid", "go.mod"), []byte("module example.com/invalid\n\nrequire\n"), 0644))
+
+	t.Run("Directory", func(t *testing.T) {
+		t.Parallel()
+
+		file, err := ReadModule(filepath.Join(dir, "cmd"))
+		assert.NoError(t, err)
+		assert.Equal(t, goMod, file.Syntax.Name)
+		assert.Equal(t, "example.com/app", file.Module.Mod.Path)
+		assert.Equal(t, "1.24.0", file.Go.Version)
+		assert.Equal(t, "go1.25.1", file.Toolchain.Name)
+		assert.Len(t, file.Require, 2)
+		assert.Equal(t, "example.com/indirect", file.Require[1].Mod.Path)
+		assert.True(t, file.Require[1].Indirect)
+		assert.Equal(t, "../direct", file.Replace[0].New.Path)
+		assert.Equal(t, "v1.0.5", file.Retract[0].High)
+		assert.Equal(t, "Broken build.", file.Retract[0].Rationale)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		t.Parallel()
+
+		file, err := ReadModule(goMod)
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com/app", file.Module.Mod.Path)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ReadModule(filepath.Join(dir, "invalid"))
+		assert.ErrorIs(t, err, ErrInvalidModule)
+	})
+
+	t.Run("Not_Found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ReadModule(filepath.Join(dir, "missing", "go.mod"))
+		assert.ErrorIs(t, err, ErrModuleNotFound)
+	})
+}
@@ -0,0 +1,124 @@
+package compiler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"sync"
+)
+
+// Port is a GOOS/GOARCH pair supported by the toolchain.
+type Port struct {
+	GOOS         string `json:"GOOS"`
+	GOARCH       string `json:"GOARCH"`
+	CgoSupported bool   `json:"CgoSupported"`
+	FirstClass   bool   `json:"FirstClass"`
+}
+
+// Ports are the ports supported by the toolchain.
+type Ports []Port
+
+// portsCache caches the ports by go executable, as they don't change during the process.
+var (
+	portsCache      = map[string]Ports{}
+	portsCacheMutex sync.Mutex
+)
+
+// Ports returns the ports supported by the given go binary (`go tool dist list -json`).
+// The result is cached per go executable for the lifetime of the process.
+// The command is canceled with the context, along with any child processes it started.
+func (c *Compiler) Ports(ctx context.Context, goBinary string) (Ports, error) {
+	executable, err := LookupGo(goBinary)
+	if err != nil {
+		return nil, err
+	}
+
+	portsCacheMutex.Lock()
+	defer portsCacheMutex.Unlock()
+
+	if ports, cached := portsCache[executable]; cached {
+		return ports, nil
+	}
+
+	cmd := exec.CommandContext(ctx, executable, "tool", "dist", "list", "-json")
+	cmd.WaitDelay = waitDelay
+	setProcessGroup(cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list ports: %w, \n\tcommand: %s", err, cmd.String())
+	}
+
+	ports := Ports{}
+	if err := json.Unmarshal(output, &ports); err != nil {
+		return nil, fmt.Errorf("unable to parse ports: %w", err)
+	}
+
+	portsCache[executable] = ports
+
+	return ports, nil
+}
+
+// GOOSes returns the supported GOOS values.
+func (p Ports) GOOSes() []string {
+	gooses := []string{}
+	for _, port := range p {
+		if !slices.Contains(gooses, port.GOOS) {
+			gooses = append(gooses, port.GOOS)
+		}
+	}
+
+	return gooses
+}
+
+// GOARCHes returns the GOARCH values supported for the given GOOS.
+func (p Ports) GOARCHes(goos string) []string {
+	goarches := []string{}
+	for _, port := range p {
+		if port.GOOS == goos && !slices.Contains(goarches, port.GOARCH) {
+			goarches = append(goarches, port.GOARCH)
+		}
+	}
+
+	return goarches
+}
+
+// Closest returns the candidate with the smallest edit distance to the value.
+// It returns an empty string if there are no candidates.
+func Closest(value string, candidates []string) string {
+	closest := ""
+	closestDistance := -1
+	for _, candidate := range candidates {
+		if distance := levenshtein(value, candidate); closestDistance == -1 || distance < closestDistance {
+			closest, closestDistance = candidate, distance
+		}
+	}
+
+	return closest
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,52 @@
+package compiler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccPorts(t *testing.T) {
+	t.Parallel()
+
+	ports, err := New().Ports(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Contains(t, ports, Port{GOOS: "linux", GOARCH: "amd64", CgoSupported: true, FirstClass: true})
+	assert.Contains(t, ports.GOOSes(), "windows")
+	assert.Contains(t, ports.GOARCHes("darwin"), "arm64")
+	assert.NotContains(t, ports.GOARCHes("darwin"), "386")
+
+	// The second call is served from the cache.
+	cached, err := New().Ports(context.Background(), "go")
+	assert.NoError(t, err)
+	assert.Same(t, &ports[0], &cached[0])
+
+	_, err = New().Ports(context.Background(), "go-does-not-exist")
+	assert.ErrorIs(t, err, ErrGoBinaryNotFound)
+
+	// A canceled context aborts listing the ports of an executable that isn't cached yet.
+	goExecutable, err := LookupGo("")
+	assert.NoError(t, err)
+	link := filepath.Join(t.TempDir(), "go")
+	assert.NoError(t, os.Symlink(goExecutable, link))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = New().Ports(ctx, link)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAccClosest(t *testing.T) {
+	t.Parallel()
+
+	candidates := []string{"amd64", "arm", "arm64", "386"}
+
+	assert.Equal(t, "amd64", Closest("amd46", candidates))
+	assert.Equal(t, "arm64", Closest("arm65", candidates))
+	assert.Equal(t, "arm", Closest("ar", candidates))
+	assert.Equal(t, "linux", Closest("linx", []string{"darwin", "linux", "windows"}))
+	assert.Equal(t, "", Closest("linux", nil))
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package compiler
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// setProcessGroup kills the process tree of the command on cancellation,
+// so that the children of `go build` (compiler, linker, cgo) are killed as well.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+	}
+}
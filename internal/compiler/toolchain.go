@@ -0,0 +1,64 @@
+package compiler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Toolchain describes the go toolchain used for builds.
+type Toolchain struct {
+	// Executable is the resolved path of the go binary.
+	Executable string
+	// Version is the output of `go version` (e.g. `go version go1.24.1 linux/amd64`).
+	Version string
+	// Env are the variables reported by `go env -json`.
+	Env map[string]string
+}
+
+// Toolchain describes the go binary of the configuration (`go version` and `go env -json`).
+// The go binary is resolved and run with the same environment as for `Compile`.
+func (c *Compiler) Toolchain(ctx context.Context, conf Config) (*Toolchain, error) {
+	executable, err := LookupGo(conf.goBinary)
+	if err != nil {
+		return nil, err
+	}
+
+	environ := conf.environ(os.Environ())
+	run := func(args ...string) ([]byte, error) {
+		cmd := exec.CommandContext(ctx, executable, args...)
+		cmd.Env = environ
+		cmd.WaitDelay = waitDelay
+		setProcessGroup(cmd)
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe toolchain: %w, \n\tcommand: %s", err, cmd.String())
+		}
+
+		return output, nil
+	}
+
+	version, err := run("version")
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := run("env", "-json")
+	if err != nil {
+		return nil, err
+	}
+
+	env := map[string]string{}
+	if err := json.Unmarshal(output, &env); err != nil {
+		return nil, fmt.Errorf("unable to parse go env: %w", err)
+	}
+
+	return &Toolchain{
+		Executable: executable,
+		Version:    strings.TrimSpace(string(version)),
+		Env:        env,
+	}, nil
+}
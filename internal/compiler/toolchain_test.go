@@ -0,0 +1,27 @@
+package compiler
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccToolchain(t *testing.T) {
+	t.Parallel()
+
+	toolchain, err := New().Toolchain(context.Background(), *NewConfig().Env(map[string]string{"GOFLAGS": "-mod=mod"}))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, toolchain.Executable)
+	assert.True(t, strings.HasPrefix(toolchain.Version, "go version "+toolchain.Env["GOVERSION"]), toolchain.Version)
+	assert.NotEmpty(t, toolchain.Env["GOROOT"])
+	assert.NotEmpty(t, toolchain.Env["GOMODCACHE"])
+	assert.Equal(t, runtime.GOOS, toolchain.Env["GOOS"])
+	// The environment of the configuration applies like for builds.
+	assert.Equal(t, "-mod=mod", toolchain.Env["GOFLAGS"])
+
+	_, err = New().Toolchain(context.Background(), *NewConfig().GoBinary("go-does-not-exist"))
+	assert.ErrorIs(t, err, ErrGoBinaryNotFound)
+}
@@ -0,0 +1,69 @@
+package compiler
+
+import (
+	"context"
+	"go/version"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// buildJSONVersion is the first go version supporting `go build -json`.
+const buildJSONVersion = "go1.24"
+
+// versionCache caches the go version by executable and environment, as it doesn't change during the process.
+// The environment is part of the key, as it may select another toolchain (e.g. `GOTOOLCHAIN`).
+var (
+	versionCache      = map[string]*versionEntry{}
+	versionCacheMutex sync.Mutex
+)
+
+// versionEntry is the cached go version of an executable and environment.
+// Its mutex is held while the version is queried, so concurrent builds query it only once
+// without blocking builds with other executables or environments.
+type versionEntry struct {
+	sync.Mutex
+	version string
+	known   bool
+}
+
+// goVersion returns the version of the go executable (e.g. `go1.24.1`) with the environment or an empty string if unknown.
+// Failures aren't cached, so a canceled query is repeated by the next build.
+func goVersion(ctx context.Context, executable string, environ []string) string {
+	key := executable + "\x00" + strings.Join(environ, "\x00")
+
+	versionCacheMutex.Lock()
+	entry, cached := versionCache[key]
+	if !cached {
+		entry = &versionEntry{}
+		versionCache[key] = entry
+	}
+	versionCacheMutex.Unlock()
+
+	entry.Lock()
+	defer entry.Unlock()
+
+	if entry.known {
+		return entry.version
+	}
+
+	cmd := exec.CommandContext(ctx, executable, "env", "GOVERSION")
+	cmd.Env = environ
+	cmd.WaitDelay = waitDelay
+	setProcessGroup(cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+
+	entry.version, entry.known = strings.TrimSpace(string(output)), true
+
+	return entry.version
+}
+
+// supportsBuildJSON returns if the go executable supports `go build -json` with the environment.
+func supportsBuildJSON(ctx context.Context, executable string, environ []string) bool {
+	goVersion := goVersion(ctx, executable, environ)
+
+	return version.IsValid(goVersion) && version.Compare(goVersion, buildJSONVersion) >= 0
+}
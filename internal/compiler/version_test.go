@@ -0,0 +1,36 @@
+package compiler
+
+import (
+	"context"
+	"go/version"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccGoVersion(t *testing.T) {
+	t.Parallel()
+
+	executable, err := LookupGo("")
+	assert.NoError(t, err)
+
+	// The variable makes the environment unique, so the cache of other tests isn't used.
+	environ := (&Config{goos: "linux", goarch: "amd64"}).environ(append(os.Environ(), "GOPACKAGER_TEST="+t.Name()))
+
+	// A failed query isn't cached.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Empty(t, goVersion(ctx, executable, environ))
+
+	cached := goVersion(context.Background(), executable, environ)
+	assert.True(t, version.IsValid(cached), cached)
+	assert.True(t, supportsBuildJSON(context.Background(), executable, environ))
+
+	// The version is cached per executable and environment.
+	assert.Equal(t, cached, goVersion(ctx, executable, environ), "served from the cache despite the canceled context")
+	assert.Empty(t, goVersion(ctx, executable, append(environ, "GOPACKAGER_TEST_OTHER=1")), "other environments are queried separately")
+
+	// The environment applies to the query like for builds.
+	assert.Empty(t, goVersion(context.Background(), executable, append(environ, "GOTOOLCHAIN=invalid")))
+}
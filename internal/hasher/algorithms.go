@@ -0,0 +1,158 @@
+package hasher
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"slices"
+	"sort"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Names of the built-in hash algorithms, which are also the keys of `CombinedHash.Hashes`.
+const (
+	// AlgorithmMD5 is MD5.
+	AlgorithmMD5 = "md5"
+	// AlgorithmSHA1 is SHA-1.
+	AlgorithmSHA1 = "sha1"
+	// AlgorithmSHA256 is SHA-256.
+	AlgorithmSHA256 = "sha256"
+	// AlgorithmSHA512 is SHA-512.
+	AlgorithmSHA512 = "sha512"
+	// AlgorithmSHA3_256 is SHA3-256.
+	AlgorithmSHA3_256 = "sha3_256"
+	// AlgorithmSHA3_512 is SHA3-512.
+	AlgorithmSHA3_512 = "sha3_512"
+	// AlgorithmBLAKE2b256 is BLAKE2b with a 256 bit digest.
+	AlgorithmBLAKE2b256 = "blake2b_256"
+	// AlgorithmBLAKE2b512 is BLAKE2b with a 512 bit digest.
+	AlgorithmBLAKE2b512 = "blake2b_512"
+	// AlgorithmCRC32C is CRC-32 with the Castagnoli polynomial as used by Google Cloud Storage.
+	AlgorithmCRC32C = "crc32c"
+)
+
+// Base64Suffix is appended to the algorithm name for the base64 encoded digest in `CombinedHash.Hashes`.
+const Base64Suffix = "_base64"
+
+// DefaultAlgorithms are the algorithms used if none are selected.
+var DefaultAlgorithms = []string{AlgorithmMD5, AlgorithmSHA1, AlgorithmSHA256, AlgorithmSHA512}
+
+// fileAlgorithms are the cryptographic algorithms the files of a directory can be hashed with in the order of preference.
+// SHA512 comes first, so the hashes of the default algorithms don't change, followed by the other algorithms
+// with a digest of at least 256 bit from the strongest to the weakest.
+var fileAlgorithms = []string{
+	AlgorithmSHA512,
+	AlgorithmSHA3_512,
+	AlgorithmBLAKE2b512,
+	AlgorithmSHA3_256,
+	AlgorithmBLAKE2b256,
+	AlgorithmSHA256,
+}
+
+var (
+	// ErrUnknownAlgorithm is returned when a selected hash algorithm is not registered.
+	ErrUnknownAlgorithm = errors.New("unknown hash algorithm")
+	// ErrDuplicateAlgorithm is returned when an algorithm is registered twice.
+	ErrDuplicateAlgorithm = errors.New("duplicate hash algorithm")
+)
+
+// crc32cTable is the table for CRC-32C, which is hardware accelerated on most CPUs.
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// registry holds the constructors of all registered algorithms by name.
+var registry = struct {
+	sync.RWMutex
+	algorithms map[string]func() hash.Hash
+}{
+	algorithms: map[string]func() hash.Hash{
+		AlgorithmMD5:        md5.New,
+		AlgorithmSHA1:       sha1.New,
+		AlgorithmSHA256:     sha256.New,
+		AlgorithmSHA512:     sha512.New,
+		AlgorithmSHA3_256:   func() hash.Hash { return sha3.New256() },
+		AlgorithmSHA3_512:   func() hash.Hash { return sha3.New512() },
+		AlgorithmBLAKE2b256: newBLAKE2b(blake2b.New256),
+		AlgorithmBLAKE2b512: newBLAKE2b(blake2b.New512),
+		AlgorithmCRC32C:     func() hash.Hash { return crc32.New(crc32cTable) },
+	},
+}
+
+// newBLAKE2b wraps the constructor of an unkeyed BLAKE2b hash, which never fails.
+func newBLAKE2b(newHash func(key []byte) (hash.Hash, error)) func() hash.Hash {
+	return func() hash.Hash {
+		h, _ := newHash(nil)
+		return h
+	}
+}
+
+// RegisterAlgorithm adds a hash algorithm that can be selected by its name.
+func RegisterAlgorithm(name string, newHash func() hash.Hash) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, exists := registry.algorithms[name]; exists {
+		return fmt.Errorf("%w: '%s'", ErrDuplicateAlgorithm, name)
+	}
+
+	registry.algorithms[name] = newHash
+
+	return nil
+}
+
+// Algorithms returns the names of all registered algorithms in sorted order.
+func Algorithms() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.algorithms))
+	for name := range registry.algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// newAlgorithm creates a hash of the registered algorithm.
+func newAlgorithm(name string) (hash.Hash, error) {
+	newHash, err := algorithm(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newHash(), nil
+}
+
+// algorithm returns the constructor of the registered algorithm.
+func algorithm(name string) (func() hash.Hash, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	newHash, ok := registry.algorithms[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownAlgorithm, name)
+	}
+
+	return newHash, nil
+}
+
+// fileAlgorithm returns the algorithm to hash the single files of a directory with for the selected algorithms.
+// It is the first selected algorithm of `fileAlgorithms` and SHA512 if none of them is selected,
+// as the combined hashes are only as strong as the digests of the files they are computed from.
+func fileAlgorithm(algorithms []string) string {
+	for _, name := range fileAlgorithms {
+		if slices.Contains(algorithms, name) {
+			return name
+		}
+	}
+
+	return AlgorithmSHA512
+}
@@ -0,0 +1,154 @@
+package hasher
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	hasher := New()
+
+	t.Run("Digests", func(t *testing.T) {
+		t.Parallel()
+
+		for name, expected := range map[string]string{
+			AlgorithmSHA3_256:   "36f028580bb02cc8272a9a020f4200e346e276ae664e45ee80745574e2f5ab80",
+			AlgorithmSHA3_512:   "9ece086e9bac491fac5c1d1046ca11d737b92a2b2ebd93f005d7b710110c0a678288166e7fbe796883a4f2e9b3ca9f484f521d0ce464345cc1aec96779149c14",
+			AlgorithmBLAKE2b256: "928b20366943e2afd11ebc0eae2e53a93bf177a4fcf35bcc64d503704e65e202",
+			AlgorithmBLAKE2b512: "a71079d42853dea26e453004338670a53814b78137ffbed07603a41d76a483aa9bc33b582f77d30a65e6f29a896c0411f38312e1d66e0bf16386c86a89bea572",
+			AlgorithmCRC32C:     "86a072c0",
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				combined, err := hasher.CombinedHash([]byte("test"), []string{name})
+				assert.NoError(t, err)
+				assert.Equal(t, expected, combined.Hashes[name])
+			})
+		}
+	})
+
+	t.Run("Selected_Only", func(t *testing.T) {
+		t.Parallel()
+
+		combined, err := hasher.HashReader(strings.NewReader("test"), []string{AlgorithmCRC32C, AlgorithmSHA256, AlgorithmCRC32C})
+		assert.NoError(t, err)
+		assert.Equal(t, &CombinedHash{
+			SHA256:       "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			SHA256Base64: "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
+			Hashes: map[string]string{
+				"crc32c":        "86a072c0",
+				"crc32c_base64": "hqBywA==",
+				"sha256":        "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+				"sha256_base64": "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
+			},
+		}, combined)
+		assert.Equal(t, "86a072c0\n9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", combined.Digests())
+	})
+
+	t.Run("Unknown_Algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := hasher.CombinedHash([]byte("test"), []string{"sha256", "md4"})
+		assert.ErrorIs(t, err, ErrUnknownAlgorithm)
+		assert.ErrorContains(t, err, "'md4'")
+
+		_, err = hasher.HashDir(t.TempDir(), DirOptions{Algorithms: []string{"md4"}})
+		assert.ErrorIs(t, err, ErrUnknownAlgorithm)
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		t.Parallel()
+
+		assert.ErrorIs(t, RegisterAlgorithm(AlgorithmSHA256, sha256.New), ErrDuplicateAlgorithm)
+
+		assert.NoError(t, RegisterAlgorithm("test_sha224", func() hash.Hash { return sha256.New224() }))
+		assert.Contains(t, Algorithms(), "test_sha224")
+
+		combined, err := hasher.CombinedHash([]byte("test"), []string{"test_sha224"})
+		assert.NoError(t, err)
+		assert.Equal(t, "90a3ed9e32b2aaf4c61c410eb925426119e1a9dc53d4286ade99a809", combined.Hashes["test_sha224"])
+	})
+
+	t.Run("File_Algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		for expected, algorithms := range map[string][]string{
+			AlgorithmSHA512:     nil,
+			AlgorithmSHA3_512:   {AlgorithmSHA3_512, AlgorithmCRC32C, AlgorithmMD5},
+			AlgorithmBLAKE2b256: {AlgorithmSHA256, AlgorithmBLAKE2b256},
+			AlgorithmSHA256:     {AlgorithmSHA256, AlgorithmMD5},
+			AlgorithmSHA3_256:   {"test_unknown", AlgorithmSHA3_256},
+		} {
+			assert.Equal(t, expected, fileAlgorithm(algorithms), algorithms)
+		}
+
+		// SHA512 is kept if selected, so the default hashes don't change.
+		assert.Equal(t, AlgorithmSHA512, fileAlgorithm([]string{AlgorithmSHA3_512, AlgorithmSHA512}))
+
+		// Weak and registered algorithms are never used for the files.
+		for _, algorithms := range [][]string{{AlgorithmCRC32C}, {AlgorithmMD5, AlgorithmSHA1}, {"test_a", AlgorithmCRC32C}} {
+			assert.Equal(t, AlgorithmSHA512, fileAlgorithm(algorithms), algorithms)
+		}
+	})
+
+	t.Run("File_Digests", func(t *testing.T) {
+		t.Parallel()
+
+		root := t.TempDir()
+		content := strings.Repeat("content", 1024)
+		assert.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte(content), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(root, "b.txt"), []byte(content), 0644))
+
+		// The files are hashed with the strongest selected algorithm only.
+		combined, err := hasher.HashDir(root, DirOptions{Algorithms: []string{AlgorithmSHA256, AlgorithmCRC32C}})
+		assert.NoError(t, err)
+		assert.Len(t, combined.Files, 2)
+		expected := sha256.New()
+		expected.Write([]byte("a.txt" + content))
+		assert.Equal(t, expected.Sum(nil), combined.Files[0].Digest)
+
+		combined, err = hasher.HashDir(root, DirOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, combined.Files[0].Digest, 64)
+
+		// The files are never hashed with CRC32C, so it only combines their digests.
+		combined, err = hasher.HashDir(root, DirOptions{Algorithms: []string{AlgorithmCRC32C}})
+		assert.NoError(t, err)
+		assert.Len(t, combined.Files[0].Digest, sha512.Size)
+
+		// A registered algorithm only hashes the digests of the files.
+		var written atomic.Int64
+		assert.NoError(t, RegisterAlgorithm("test_counting", func() hash.Hash { return &countingHash{Hash: sha256.New(), written: &written} }))
+
+		_, err = hasher.HashDir(root, DirOptions{Algorithms: []string{"test_counting"}})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3*sha512.Size), written.Swap(0), "only the digests of the root and both files")
+
+		_, err = hasher.HashDir(root, DirOptions{Algorithms: []string{"test_counting", AlgorithmSHA256}})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3*sha256.Size), written.Load(), "only the digests of the root and both files")
+	})
+}
+
+// countingHash counts the bytes written to the hash.
+type countingHash struct {
+	hash.Hash
+	written *atomic.Int64
+}
+
+func (c *countingHash) Write(p []byte) (int, error) {
+	c.written.Add(int64(len(p)))
+
+	return c.Hash.Write(p)
+}
@@ -0,0 +1,179 @@
+package hasher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// cacheVersion is increased on changes of the cache format or the digests, which invalidates all caches.
+const cacheVersion = 2
+
+// racyWindow is the time in which a file modified after an earlier modification may keep the same mtime,
+// depending on the resolution of the filesystem. Digests of files modified within it are not cached.
+const racyWindow = 2 * time.Second
+
+// resetKeyFile is the file of the cache directory with the key of the last reset.
+const resetKeyFile = "reset-key"
+
+// cacheFilePattern matches the cache files and the temporary files written by save,
+// which are the only files removed on a reset.
+var cacheFilePattern = regexp.MustCompile(`^[0-9a-f]{64}\.json(\.[0-9]+\.tmp)?$`)
+
+// cacheEntry is the digest of a file along with the metadata that identifies the version it was computed for.
+type cacheEntry struct {
+	Inode   uint64 `json:"inode"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"`
+	Mode    uint32 `json:"mode"`
+	Digest  []byte `json:"digest"`
+}
+
+// cacheFile is the content of the cache file of a directory.
+type cacheFile struct {
+	Version   int                   `json:"version"`
+	Root      string                `json:"root"`
+	Algorithm string                `json:"algorithm"`
+	Entries   map[string]cacheEntry `json:"entries"`
+}
+
+// dirCache holds the cached digests of the files of a directory, keyed by their path relative to it.
+// A nil dirCache disables caching.
+type dirCache struct {
+	file      string
+	root      string
+	algorithm string
+	started   time.Time
+	entries   map[string]cacheEntry
+}
+
+// openDirCache loads the cache of root for the digests of the algorithm from the cache directory.
+// A missing, outdated or corrupted cache results in an empty cache, as it is only an optimization.
+func openDirCache(cacheDir, root, algorithm string) *dirCache {
+	name := sha256.Sum256([]byte(root + "\x00" + algorithm))
+	cache := &dirCache{
+		file:      filepath.Join(cacheDir, hex.EncodeToString(name[:])+".json"),
+		root:      root,
+		algorithm: algorithm,
+		started:   time.Now(),
+		entries:   map[string]cacheEntry{},
+	}
+
+	content, err := os.ReadFile(cache.file)
+	if err != nil {
+		return cache
+	}
+
+	var stored cacheFile
+	if err := json.Unmarshal(content, &stored); err != nil || stored.Version != cacheVersion || stored.Root != root || stored.Algorithm != algorithm {
+		return cache
+	}
+
+	if stored.Entries != nil {
+		cache.entries = stored.Entries
+	}
+
+	return cache
+}
+
+// lookup returns the cache entry of the file if the file didn't change since its digest was computed.
+func (c *dirCache) lookup(rel string, info os.FileInfo) (cacheEntry, bool) {
+	if c == nil || !info.Mode().IsRegular() {
+		return cacheEntry{}, false
+	}
+
+	entry, ok := c.entries[rel]
+	if !ok || entry.Inode != fileID(info) || entry.Size != info.Size() ||
+		entry.ModTime != info.ModTime().UnixNano() || entry.Mode != uint32(info.Mode()) {
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// entry creates the cache entry of the file, which is not cacheable if it could be modified
+// again without changing its mtime.
+func (c *dirCache) entry(info os.FileInfo, digest []byte) (cacheEntry, bool) {
+	if c == nil || !info.Mode().IsRegular() || c.started.Sub(info.ModTime()) < racyWindow {
+		return cacheEntry{}, false
+	}
+
+	return cacheEntry{
+		Inode:   fileID(info),
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		Mode:    uint32(info.Mode()),
+		Digest:  digest,
+	}, true
+}
+
+// save replaces the cache with the entries, so files that no longer exist are dropped.
+// The cache is written to a temporary file that is renamed, so concurrent processes
+// never read a partial cache and the last writer wins.
+func (c *dirCache) save(entries map[string]cacheEntry) error {
+	if c == nil {
+		return nil
+	}
+
+	content, err := json.Marshal(cacheFile{Version: cacheVersion, Root: c.root, Algorithm: c.algorithm, Entries: entries})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.file), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.file), filepath.Base(c.file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.file)
+}
+
+// ResetCache removes the cached digests of the cache directory if the key differs from the key of the last reset
+// and reports whether they were removed. Only the files written by the cache are removed, other files and the
+// directory itself are kept. The key is stored in the cache directory, so the cache is reset once per key.
+func ResetCache(cacheDir, key string) (bool, error) {
+	keyFile := filepath.Join(cacheDir, resetKeyFile)
+	if stored, err := os.ReadFile(keyFile); err == nil && string(stored) == key {
+		return false, nil
+	}
+
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !cacheFilePattern.MatchString(entry.Name()) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(cacheDir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return false, err
+		}
+	}
+
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return false, err
+	}
+
+	return true, os.WriteFile(keyFile, []byte(key), 0o644)
+}
@@ -0,0 +1,239 @@
+package hasher
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDirCache(t *testing.T) {
+	t.Parallel()
+
+	hasher := New()
+
+	// writeFile writes the file with a modification time outside of the racy window, so it is cached.
+	writeFile := func(t *testing.T, path, content string) {
+		t.Helper()
+
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	t.Run("Unchanged_Files_From_Cache", func(t *testing.T) {
+		t.Parallel()
+
+		root, cacheDir := t.TempDir(), t.TempDir()
+		writeFile(t, filepath.Join(root, "a.txt"), "aaa")
+		writeFile(t, filepath.Join(root, "sub", "b.txt"), "bbb")
+
+		uncached, err := hasher.HashDir(root, DirOptions{})
+		assert.NoError(t, err)
+		cached, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		assert.Equal(t, uncached, cached)
+
+		// Changing the content without changing the metadata proves the digest is taken from the cache.
+		writeFile(t, filepath.Join(root, "a.txt"), "zzz")
+		cachedAgain, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		assert.Equal(t, cached, cachedAgain)
+
+		reset, err := ResetCache(cacheDir, "1")
+		assert.NoError(t, err)
+		assert.True(t, reset)
+		cleared, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		assert.NotEqual(t, cached, cleared)
+	})
+
+	t.Run("Cache_Per_Algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		root, cacheDir := t.TempDir(), t.TempDir()
+		writeFile(t, filepath.Join(root, "a.txt"), "aaa")
+
+		uncached, err := hasher.HashDir(root, DirOptions{Algorithms: []string{AlgorithmSHA256}})
+		assert.NoError(t, err)
+
+		_, err = hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		cached, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir, Algorithms: []string{AlgorithmSHA256}})
+		assert.NoError(t, err)
+		assert.Equal(t, uncached, cached, "digests of another algorithm must not be used")
+
+		assert.Len(t, openDirCache(cacheDir, root, AlgorithmSHA512).entries, 1)
+		assert.Len(t, openDirCache(cacheDir, root, AlgorithmSHA256).entries, 1)
+	})
+
+	t.Run("Reset_Once_Per_Key", func(t *testing.T) {
+		t.Parallel()
+
+		root, cacheDir := t.TempDir(), t.TempDir()
+		writeFile(t, filepath.Join(root, "a.txt"), "aaa")
+
+		// Files not written by the cache are kept on reset.
+		foreign := []string{"notes.json", "README.md", filepath.Join("sub", "0000000000000000000000000000000000000000000000000000000000000000.json")}
+		for _, name := range foreign {
+			writeFile(t, filepath.Join(cacheDir, name), "keep")
+		}
+
+		_, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		assert.Len(t, openDirCache(cacheDir, root, AlgorithmSHA512).entries, 1)
+
+		reset, err := ResetCache(cacheDir, "2026-10-17")
+		assert.NoError(t, err)
+		assert.True(t, reset)
+		assert.Empty(t, openDirCache(cacheDir, root, AlgorithmSHA512).entries)
+		for _, name := range foreign {
+			assert.FileExists(t, filepath.Join(cacheDir, name))
+		}
+
+		// The same key doesn't reset the rebuilt cache again.
+		_, err = hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		reset, err = ResetCache(cacheDir, "2026-10-17")
+		assert.NoError(t, err)
+		assert.False(t, reset)
+		assert.Len(t, openDirCache(cacheDir, root, AlgorithmSHA512).entries, 1)
+
+		reset, err = ResetCache(cacheDir, "2026-10-18")
+		assert.NoError(t, err)
+		assert.True(t, reset)
+		assert.Empty(t, openDirCache(cacheDir, root, AlgorithmSHA512).entries)
+		assert.DirExists(t, cacheDir)
+	})
+
+	t.Run("Reset_Missing_Directory", func(t *testing.T) {
+		t.Parallel()
+
+		cacheDir := filepath.Join(t.TempDir(), "cache")
+
+		reset, err := ResetCache(cacheDir, "1")
+		assert.NoError(t, err)
+		assert.True(t, reset)
+		assert.FileExists(t, filepath.Join(cacheDir, resetKeyFile))
+	})
+
+	t.Run("Changed_Size", func(t *testing.T) {
+		t.Parallel()
+
+		root, cacheDir := t.TempDir(), t.TempDir()
+		writeFile(t, filepath.Join(root, "a.txt"), "aaa")
+
+		before, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+
+		writeFile(t, filepath.Join(root, "a.txt"), "aaaa")
+		after, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("Racy_Files_Not_Cached", func(t *testing.T) {
+		t.Parallel()
+
+		root, cacheDir := t.TempDir(), t.TempDir()
+		path := filepath.Join(root, "a.txt")
+		modTime := time.Now()
+		assert.NoError(t, os.WriteFile(path, []byte("aaa"), 0644))
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+
+		before, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+
+		assert.NoError(t, os.WriteFile(path, []byte("zzz"), 0644))
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+		after, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("Removed_Files_Dropped", func(t *testing.T) {
+		t.Parallel()
+
+		root, cacheDir := t.TempDir(), t.TempDir()
+		writeFile(t, filepath.Join(root, "a.txt"), "aaa")
+		writeFile(t, filepath.Join(root, "b.txt"), "bbb")
+
+		_, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		assert.Len(t, openDirCache(cacheDir, root, AlgorithmSHA512).entries, 2)
+
+		assert.NoError(t, os.Remove(filepath.Join(root, "b.txt")))
+		_, err = hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		assert.Len(t, openDirCache(cacheDir, root, AlgorithmSHA512).entries, 1)
+	})
+
+	t.Run("Corrupted_Cache", func(t *testing.T) {
+		t.Parallel()
+
+		root, cacheDir := t.TempDir(), t.TempDir()
+		writeFile(t, filepath.Join(root, "a.txt"), "aaa")
+
+		expected, err := hasher.HashDir(root, DirOptions{})
+		assert.NoError(t, err)
+
+		cache := openDirCache(cacheDir, root, AlgorithmSHA512)
+		assert.NoError(t, os.WriteFile(cache.file, []byte("{not json"), 0644))
+		actual, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+		assert.Len(t, openDirCache(cacheDir, root, AlgorithmSHA512).entries, 1)
+	})
+
+	t.Run("Cache_Inside_Root", func(t *testing.T) {
+		t.Parallel()
+
+		root := t.TempDir()
+		writeFile(t, filepath.Join(root, "a.txt"), "aaa")
+
+		expected, err := hasher.HashDir(root, DirOptions{})
+		assert.NoError(t, err)
+
+		for range 2 {
+			actual, err := hasher.HashDir(root, DirOptions{CacheDir: filepath.Join(root, ".cache")})
+			assert.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("Concurrent_Use", func(t *testing.T) {
+		t.Parallel()
+
+		root, cacheDir := t.TempDir(), t.TempDir()
+		for _, name := range []string{"a.txt", "b.txt", "sub/c.txt"} {
+			writeFile(t, filepath.Join(root, name), name)
+		}
+
+		expected, err := hasher.HashDir(root, DirOptions{})
+		assert.NoError(t, err)
+
+		wg := sync.WaitGroup{}
+		for range 8 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				actual, err := hasher.HashDir(root, DirOptions{CacheDir: cacheDir})
+				assert.NoError(t, err)
+				assert.Equal(t, expected, actual)
+			}()
+		}
+
+		wg.Wait()
+
+		entries, err := os.ReadDir(cacheDir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1, "temporary files must be removed")
+		assert.Regexp(t, cacheFilePattern, entries[0].Name())
+	})
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package hasher
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileID returns the inode of the file, so a replaced file with the same size and mtime isn't mistaken for the cached one.
+func fileID(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Ino) //nolint:unconvert
+	}
+
+	return 0
+}
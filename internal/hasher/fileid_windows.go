@@ -0,0 +1,11 @@
+//go:build windows
+
+package hasher
+
+import "os"
+
+// fileID returns 0, as the file index isn't part of the file info on Windows.
+// Cached digests are identified by size, mtime and mode only.
+func fileID(info os.FileInfo) uint64 {
+	return 0
+}
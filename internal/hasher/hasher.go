@@ -2,32 +2,28 @@ package hasher
 
 import (
 	"bytes"
-	"crypto/md5"
-	"crypto/sha1"
-	"crypto/sha256"
-	"crypto/sha512"
-	"encoding/base64"
-	"encoding/hex"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"sort"
+	"strings"
+	"sync"
 )
 
 // HasherI is the interface for Hasher.
 type HasherI interface {
-	ReadFile(path string) ([]byte, error)
-	MD5(binaryContent []byte) string
-	SHA1(binaryContent []byte) string
-	SHA256(binaryContent []byte) string
-	SHA512(binaryContent []byte) string
-	SHA256Base64(binaryContent []byte) string
-	SHA512Base64(binaryContent []byte) string
-	CombinedHash(binaryContent []byte) CombinedHash
-	HashDir(root string) (*CombinedHash, error)
+	HashReader(r io.Reader, algorithms []string) (*CombinedHash, error)
+	HashFile(path string, algorithms []string) (*CombinedHash, error)
+	CombinedHash(binaryContent []byte, algorithms []string) (*CombinedHash, error)
+	HashDir(root string, opts DirOptions) (*CombinedHash, error)
+	HashFiles(root string, files []string, opts FilesOptions) (*CombinedHash, error)
 }
 
 // CombinedHash is a struct for the combined hash.
+// The fields of the default algorithms are empty if the algorithm isn't selected.
 type CombinedHash struct {
 	MD5          string
 	SHA1         string
@@ -35,101 +31,143 @@ type CombinedHash struct {
 	SHA512       string
 	SHA256Base64 string
 	SHA512Base64 string
+	// Hashes are the hex encoded digests of all selected algorithms by their name
+	// and the base64 encoded digests by their name with `Base64Suffix`.
+	Hashes map[string]string
+	// Files are the hashed files and symlinks of a directory in sorted order of their path.
+	// It is empty for the hashes of a single file or content.
+	Files []FileDigest
 }
 
-// Hasher is a type for hashing files.
-type Hasher struct{}
-
-// New creates a new Hasher instance.
-func New() *Hasher {
-	return &Hasher{}
+// FileDigest is the digest of a single file of a directory.
+type FileDigest struct {
+	// Path is the path relative to the root of the directory with forward slashes.
+	Path string
+	// Size is the size of the file in bytes, which is the length of the target for symlinks.
+	Size int64
+	// Mode is the mode of the file.
+	Mode os.FileMode
+	// Digest is the digest of the path and the content of the file with the algorithm of `fileAlgorithm`.
+	Digest []byte
 }
 
-// ReadFile reads a file from the filesystem.
-// If file is ZIP file, it reads the content of all files in the ZIP file.
-func (h *Hasher) ReadFile(path string) ([]byte, error) {
-	binaryContent, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
+// Digests returns the hex encoded digests in sorted order of the algorithms, one per line.
+func (c *CombinedHash) Digests() string {
+	names := make([]string, 0, len(c.Hashes))
+	for name := range c.Hashes {
+		if !strings.HasSuffix(name, Base64Suffix) {
+			names = append(names, name)
+		}
 	}
+	sort.Strings(names)
 
-	return binaryContent, nil
-}
-
-// MD5 hashes the binary content with MD5 and returns the hexadecimal encoded string.
-func (h *Hasher) MD5(binaryContent []byte) string {
-	hashMD5 := md5.New()
-	hashMD5.Write(binaryContent)
-	hash := hex.EncodeToString(hashMD5.Sum(nil))
+	digests := make([]string, 0, len(names))
+	for _, name := range names {
+		digests = append(digests, c.Hashes[name])
+	}
 
-	return hash
+	return strings.Join(digests, "\n")
 }
 
-// SHA1 hashes the binary content with SHA1 and returns the hexadecimal encoded string.
-func (h *Hasher) SHA1(binaryContent []byte) string {
-	hashSHA1 := sha1.New()
-	hashSHA1.Write(binaryContent)
-	hash := hex.EncodeToString(hashSHA1.Sum(nil))
-
-	return hash
+// DirOptions configures which files of a directory are hashed.
+type DirOptions struct {
+	// IgnoreFiles are the names of ignore files (e.g. `.gitignore`) whose patterns exclude files.
+	// See `DockerIgnoreFile` for the difference between ignore files.
+	IgnoreFiles []string
+	// Excludes are additional patterns in the format of `.gitignore` relative to the root.
+	Excludes []string
+	// ExcludePaths are files that are always excluded, like the binary built into the directory.
+	ExcludePaths []string
+	// Concurrency is the number of files hashed in parallel, defaults to the number of CPUs.
+	Concurrency int
+	// CacheDir is the directory to cache the digests of unchanged files in between runs.
+	// Caching is disabled if it is empty.
+	CacheDir string
+	// Algorithms are the algorithms of the combined hash, defaults to `DefaultAlgorithms`.
+	Algorithms []string
 }
 
-// SHA256 hashes the binary content with SHA256 and returns the hexadecimal encoded string.
-func (h *Hasher) SHA256(binaryContent []byte) string {
-	hashSHA256 := sha256.New()
-	hashSHA256.Write(binaryContent)
-	hash := hex.EncodeToString(hashSHA256.Sum(nil))
-
-	return hash
+// FilesOptions configures how a list of files is hashed.
+type FilesOptions struct {
+	// Concurrency is the number of files hashed in parallel, defaults to the number of CPUs.
+	Concurrency int
+	// CacheDir is the directory to cache the digests of unchanged files in between runs.
+	// Caching is disabled if it is empty.
+	CacheDir string
+	// Algorithms are the algorithms of the combined hash, defaults to `DefaultAlgorithms`.
+	Algorithms []string
 }
 
-// SHA512 hashes the binary content with SHA512 and returns the hexadecimal encoded string..
-func (h *Hasher) SHA512(binaryContent []byte) string {
-	hashSHA512 := sha512.New()
-	hashSHA512.Write(binaryContent)
-	hash := hex.EncodeToString(hashSHA512.Sum(nil))
+// copyBufferSize is the size of the buffer to stream file contents into the hashes.
+const copyBufferSize = 32 * 1024
 
-	return hash
+// Hasher is a type for hashing files.
+type Hasher struct{}
+
+// New creates a new Hasher instance.
+func New() *Hasher {
+	return &Hasher{}
 }
 
-// SHA256Base64 hashes the binary content with SHA256 and encodes it with base64.
-func (h *Hasher) SHA256Base64(binaryContent []byte) string {
-	hashSHA256 := sha256.New()
-	hashSHA256.Write(binaryContent)
+// HashReader hashes everything read from r with the algorithms in a single pass.
+func (h *Hasher) HashReader(r io.Reader, algorithms []string) (*CombinedHash, error) {
+	digests, err := newMultiHash(algorithms)
+	if err != nil {
+		return nil, err
+	}
 
-	hashBytes := hashSHA256.Sum(nil)
-	hash := base64.StdEncoding.EncodeToString(hashBytes)
+	if _, err := io.Copy(digests, r); err != nil {
+		return nil, err
+	}
 
-	return hash
+	combinedHash := digests.Sum()
+	return &combinedHash, nil
 }
 
-// SHA512Base64 hashes the binary content with SHA512 and encodes it with base64.
-func (h *Hasher) SHA512Base64(binaryContent []byte) string {
-	hashSHA256 := sha512.New()
-	hashSHA256.Write(binaryContent)
-
-	hashBytes := hashSHA256.Sum(nil)
-	hash := base64.StdEncoding.EncodeToString(hashBytes)
+// HashFile hashes the content of the file without reading it into memory.
+func (h *Hasher) HashFile(path string, algorithms []string) (*CombinedHash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
 
-	return hash
+	return h.HashReader(f, algorithms)
 }
 
-// CombinedHash hashes the binary content with all available algorithms.
-func (h *Hasher) CombinedHash(binaryContent []byte) CombinedHash {
-	return CombinedHash{
-		MD5:          h.MD5(binaryContent),
-		SHA1:         h.SHA1(binaryContent),
-		SHA256:       h.SHA256(binaryContent),
-		SHA512:       h.SHA512(binaryContent),
-		SHA256Base64: h.SHA256Base64(binaryContent),
-		SHA512Base64: h.SHA512Base64(binaryContent),
-	}
+// CombinedHash hashes the binary content with the algorithms.
+func (h *Hasher) CombinedHash(binaryContent []byte, algorithms []string) (*CombinedHash, error) {
+	return h.HashReader(bytes.NewReader(binaryContent), algorithms)
 }
 
 // HashDir hashes the contents of a directory recursively.
-func (h *Hasher) HashDir(root string) (*CombinedHash, error) {
+// Files and directories excluded by the options are skipped.
+func (h *Hasher) HashDir(root string, opts DirOptions) (*CombinedHash, error) {
+	excludePaths := map[string]bool{}
+	// The cache is excluded as well, as it changes with every run if it is inside of root.
+	for _, excludePath := range slices.Concat(opts.ExcludePaths, []string{opts.CacheDir}) {
+		if excludePath == "" {
+			continue
+		}
+
+		abs, err := filepath.Abs(excludePath)
+		if err != nil {
+			return nil, err
+		}
+		excludePaths[abs] = true
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// The excludes are evaluated separately, so they can't be negated by ignore files.
+	excludes := parseIgnoreRules(opts.Excludes, "", false)
+	rules := []ignoreRule{}
+
 	var paths []string
-	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+	err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -137,6 +175,33 @@ func (h *Hasher) HashDir(root string) (*CombinedHash, error) {
 		if err != nil {
 			return err
 		}
+
+		if rel != "." && (excludePaths[filepath.Join(absRoot, rel)] || ignored(excludes, filepath.ToSlash(rel), d.IsDir()) || ignored(rules, filepath.ToSlash(rel), d.IsDir())) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			base := filepath.ToSlash(rel)
+			if base == "." {
+				base = ""
+			}
+
+			for _, ignoreFile := range opts.IgnoreFiles {
+				if ignoreFile == DockerIgnoreFile && base != "" {
+					continue
+				}
+
+				ignoreRules, err := readIgnoreRules(filepath.Join(path, ignoreFile), base, ignoreFile == DockerIgnoreFile)
+				if err != nil {
+					return err
+				}
+				rules = append(rules, ignoreRules...)
+			}
+		}
+
 		paths = append(paths, rel)
 		return nil
 	})
@@ -144,39 +209,190 @@ func (h *Hasher) HashDir(root string) (*CombinedHash, error) {
 		return nil, err
 	}
 
-	sort.Strings(paths)
+	var cache *dirCache
+	if opts.CacheDir != "" {
+		cache = openDirCache(opts.CacheDir, absRoot, fileAlgorithm(opts.Algorithms))
+	}
+
+	return h.hashPaths(root, paths, opts.Algorithms, opts.Concurrency, cache)
+}
 
-	var hashBuffer bytes.Buffer
-	for _, path := range paths {
-		hasher := sha512.New()
-		fullPath := filepath.Join(root, path)
-		info, err := os.Lstat(fullPath)
+// HashFiles hashes exactly the given files.
+// The files are identified by their path relative to root, so the hash doesn't depend on the location of root.
+func (h *Hasher) HashFiles(root string, files []string, opts FilesOptions) (*CombinedHash, error) {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		rel, err := filepath.Rel(root, file)
 		if err != nil {
 			return nil, err
 		}
+		paths = append(paths, rel)
+	}
 
-		hasher.Write([]byte(path))
+	var cache *dirCache
+	if opts.CacheDir != "" {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			return nil, err
+		}
 
-		if info.Mode().IsRegular() {
-			f, err := os.Open(fullPath)
-			if err != nil {
-				return nil, err
-			}
-			defer f.Close()
-			if _, err := io.Copy(hasher, f); err != nil {
-				return nil, err
+		cache = openDirCache(opts.CacheDir, absRoot, fileAlgorithm(opts.Algorithms))
+	}
+
+	return h.hashPaths(root, paths, opts.Algorithms, opts.Concurrency, cache)
+}
+
+// hashPaths hashes the paths relative to root with their content.
+// The paths are hashed in parallel by up to `concurrency` workers and their digests
+// are combined in sorted order, so the result doesn't depend on the scheduling.
+// Only the digests are kept in memory, so the memory doesn't grow with the size of the files.
+// Digests of files that didn't change are taken from the cache, which is updated afterwards.
+// The files are hashed with a single algorithm of `fileAlgorithm`, only their digests are combined with the algorithms.
+func (h *Hasher) hashPaths(root string, paths []string, algorithms []string, concurrency int, cache *dirCache) (*CombinedHash, error) {
+	digests, err := newMultiHash(algorithms)
+	if err != nil {
+		return nil, err
+	}
+
+	newPathHash, err := algorithm(fileAlgorithm(algorithms))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	concurrency = min(concurrency, len(paths))
+
+	pathDigests := make([]FileDigest, len(paths))
+	cacheEntries := make([]*cacheEntry, len(paths))
+	indexes := make(chan int)
+	done := make(chan struct{})
+	var errOnce sync.Once
+	var firstErr error
+	wg := sync.WaitGroup{}
+
+	for range concurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			pathHash := newPathHash()
+			buf := make([]byte, copyBufferSize)
+			for i := range indexes {
+				pathDigest, entry, err := hashCachedPath(pathHash, root, paths[i], buf, cache)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						close(done)
+					})
+
+					return
+				}
+
+				pathDigests[i], cacheEntries[i] = pathDigest, entry
 			}
-		} else if info.Mode()&os.ModeSymlink != 0 {
-			target, err := os.Readlink(fullPath)
-			if err != nil {
-				return nil, err
+		}()
+	}
+
+feed:
+	for i := range paths {
+		select {
+		case indexes <- i:
+		case <-done:
+			break feed
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	files := make([]FileDigest, 0, len(pathDigests))
+	for _, pathDigest := range pathDigests {
+		digests.Write(pathDigest.Digest)
+
+		if !pathDigest.Mode.IsDir() {
+			files = append(files, pathDigest)
+		}
+	}
+
+	if cache != nil {
+		entries := map[string]cacheEntry{}
+		for i, entry := range cacheEntries {
+			if entry != nil {
+				entries[paths[i]] = *entry
 			}
-			hasher.Write([]byte("->" + target))
 		}
 
-		hashBuffer.Write(hasher.Sum(nil))
+		// Failing to update the cache only slows down the next run.
+		_ = cache.save(entries)
 	}
 
-	combinedHash := h.CombinedHash(hashBuffer.Bytes())
+	combinedHash := digests.Sum()
+	combinedHash.Files = files
+
 	return &combinedHash, nil
 }
+
+// hashCachedPath returns the digest of the path from the cache or by hashing it with pathHash,
+// along with the cache entry to keep, which is nil if the file isn't cacheable.
+func hashCachedPath(pathHash hash.Hash, root, path string, buf []byte, cache *dirCache) (FileDigest, *cacheEntry, error) {
+	info, err := os.Lstat(filepath.Join(root, path))
+	if err != nil {
+		return FileDigest{}, nil, err
+	}
+
+	pathDigest := FileDigest{Path: filepath.ToSlash(path), Size: info.Size(), Mode: info.Mode()}
+	if entry, ok := cache.lookup(path, info); ok {
+		pathDigest.Digest = entry.Digest
+		return pathDigest, &entry, nil
+	}
+
+	pathHash.Reset()
+	if err := hashPath(pathHash, root, path, info, buf); err != nil {
+		return FileDigest{}, nil, err
+	}
+
+	pathDigest.Digest = pathHash.Sum(nil)
+	if entry, ok := cache.entry(info, pathDigest.Digest); ok {
+		return pathDigest, &entry, nil
+	}
+
+	return pathDigest, nil, nil
+}
+
+// hashPath writes the path relative to root and its content into w, using buf to copy the content.
+// Symlinks are hashed by their target and directories only by their path.
+func hashPath(w io.Writer, root, path string, info os.FileInfo, buf []byte) error {
+	fullPath := filepath.Join(root, path)
+	io.WriteString(w, path)
+
+	switch {
+	case info.Mode().IsRegular():
+		f, err := os.Open(fullPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		// Hide `WriteTo` of the file, which would allocate a new buffer for every file.
+		_, err = io.CopyBuffer(w, struct{ io.Reader }{f}, buf)
+		return err
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(fullPath)
+		if err != nil {
+			return err
+		}
+
+		io.WriteString(w, "->"+target)
+	}
+
+	return nil
+}
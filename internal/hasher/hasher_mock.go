@@ -1,72 +1,56 @@
 package hasher
 
-import "github.com/stretchr/testify/mock"
+import (
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
 
 // MockHasher is an mock type for the Hasher type.
 type MockHasher struct {
 	mock.Mock
 }
 
-// Mocks the ReadFile method.
-func (m *MockHasher) ReadFile(path string) ([]byte, error) {
-	ret := m.Called(path)
+// Mocks the HashReader method.
+func (m *MockHasher) HashReader(r io.Reader, algorithms []string) (*CombinedHash, error) {
+	ret := m.Called(r, algorithms)
 	if ret.Get(0) == nil {
 		return nil, ret.Error(1)
 	}
 
-	return ret.Get(0).([]byte), ret.Error(1) //nolint:forcetypeassert
-}
-
-// Mocks the MD5 method.
-func (m *MockHasher) MD5(binaryContent []byte) string {
-	ret := m.Called(binaryContent)
-
-	return ret.String(0)
-}
-
-// Mocks the SHA1 method.
-func (m *MockHasher) SHA1(binaryContent []byte) string {
-	ret := m.Called(binaryContent)
-
-	return ret.String(0)
-}
-
-// Mocks the SHA256 method.
-func (m *MockHasher) SHA256(binaryContent []byte) string {
-	ret := m.Called(binaryContent)
-
-	return ret.String(0)
-}
-
-// Mocks the SHA512 method.
-func (m *MockHasher) SHA512(binaryContent []byte) string {
-	ret := m.Called(binaryContent)
-
-	return ret.String(0)
+	return ret.Get(0).(*CombinedHash), ret.Error(1) //nolint:forcetypeassert
 }
 
-// Mocks the SHA256Base64 method.
-func (m *MockHasher) SHA256Base64(binaryContent []byte) string {
-	ret := m.Called(binaryContent)
+// Mocks the HashFile method.
+func (m *MockHasher) HashFile(path string, algorithms []string) (*CombinedHash, error) {
+	ret := m.Called(path, algorithms)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
 
-	return ret.String(0)
+	return ret.Get(0).(*CombinedHash), ret.Error(1) //nolint:forcetypeassert
 }
 
-// Mocks the SHA512Base64 method.
-func (m *MockHasher) SHA512Base64(binaryContent []byte) string {
-	ret := m.Called(binaryContent)
+func (m *MockHasher) CombinedHash(binaryContent []byte, algorithms []string) (*CombinedHash, error) {
+	ret := m.Called(binaryContent, algorithms)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
 
-	return ret.String(0)
+	return ret.Get(0).(*CombinedHash), ret.Error(1) //nolint:forcetypeassert
 }
 
-func (m *MockHasher) CombinedHash(binaryContent []byte) CombinedHash {
-	ret := m.Called(binaryContent)
+func (m *MockHasher) HashDir(root string, opts DirOptions) (*CombinedHash, error) {
+	ret := m.Called(root, opts)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
 
-	return ret.Get(0).(CombinedHash) //nolint:forcetypeassert
+	return ret.Get(0).(*CombinedHash), ret.Error(1) //nolint:forcetypeassert
 }
 
-func (m *MockHasher) HashDir(root string) (*CombinedHash, error) {
-	ret := m.Called(root)
+func (m *MockHasher) HashFiles(root string, files []string, opts FilesOptions) (*CombinedHash, error) {
+	ret := m.Called(root, files, opts)
 	if ret.Get(0) == nil {
 		return nil, ret.Error(1)
 	}
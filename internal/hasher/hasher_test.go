@@ -2,9 +2,14 @@ package hasher
 
 import (
 	"archive/zip"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -21,21 +26,26 @@ func TestAccHasher(t *testing.T) {
 
 	hasher := New()
 
-	t.Run("ReadFile", func(t *testing.T) {
+	t.Run("HashFile", func(t *testing.T) {
 		t.Parallel()
 
 		t.Run("Plane_Success", func(t *testing.T) {
 			t.Parallel()
 
-			content, err := hasher.ReadFile("hasher_test.go")
+			content, err := os.ReadFile("hasher_test.go")
 			assert.NoError(t, err)
-			assert.NotNil(t, content)
+
+			combined, err := hasher.HashFile("hasher_test.go", nil)
+			assert.NoError(t, err)
+			expected, err := hasher.CombinedHash(content, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, combined)
 		})
 
 		t.Run("ZIP_Success", func(t *testing.T) {
 			t.Parallel()
 
-			testFileName := "unit_test.zip"
+			testFileName := filepath.Join(t.TempDir(), "unit_test.zip")
 
 			file, err := os.Create(testFileName)
 			assert.NoError(t, err)
@@ -57,35 +67,68 @@ func TestAccHasher(t *testing.T) {
 			zipWriter.Close()
 			file.Close()
 
-			t.Cleanup(func() {
-				os.Remove(testFileName)
-			})
+			content, err := os.ReadFile(testFileName)
+			assert.NoError(t, err)
 
-			content, err := hasher.ReadFile(testFileName)
+			combined, err := hasher.HashFile(testFileName, nil)
+			assert.NoError(t, err)
+			expected, err := hasher.CombinedHash(content, nil)
 			assert.NoError(t, err)
-			assert.NotNil(t, content)
+			assert.Equal(t, expected, combined)
 		})
 
 		t.Run("Failure", func(t *testing.T) {
 			t.Parallel()
 
-			_, err := hasher.ReadFile("does_not_exist.txt")
+			_, err := hasher.HashFile("does_not_exist.txt", nil)
 			assert.Error(t, err)
 		})
 	})
 
+	t.Run("HashReader", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Success", func(t *testing.T) {
+			t.Parallel()
+
+			combined, err := hasher.HashReader(strings.NewReader("test"), nil)
+			assert.NoError(t, err)
+			expected, err := hasher.CombinedHash([]byte("test"), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, combined)
+		})
+
+		t.Run("Failure", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := hasher.HashReader(iotest.ErrReader(errors.New("read failed")), nil)
+			assert.EqualError(t, err, "read failed")
+		})
+	})
+
 	t.Run("CombinedHash", func(t *testing.T) {
 		t.Parallel()
 
 		content := []byte("test")
-		combined := hasher.CombinedHash(content)
-		assert.Equal(t, CombinedHash{
+		combined, err := hasher.CombinedHash(content, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, &CombinedHash{
 			MD5:          "098f6bcd4621d373cade4e832627b4f6",
 			SHA1:         "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
 			SHA256:       "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
 			SHA512:       "ee26b0dd4af7e749aa1a8ee3c10ae9923f618980772e473f8819a5d4940e0db27ac185f8a0e1d5f84f88bc887fd67b143732c304cc5fa9ad8e6f57f50028a8ff",
 			SHA256Base64: "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
 			SHA512Base64: "7iaw3Ur350mqGo7jwQrpkj9hiYB3Lkc/iBml1JQODbJ6wYX4oOHV+E+IvIh/1nsUNzLDBMxfqa2Ob1f1ACio/w==",
+			Hashes: map[string]string{
+				"md5":           "098f6bcd4621d373cade4e832627b4f6",
+				"md5_base64":    "CY9rzUYh03PK3k6DJie09g==",
+				"sha1":          "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
+				"sha1_base64":   "qUqP5cyxm6YcTAhz05Hph5gvu9M=",
+				"sha256":        "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+				"sha256_base64": "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
+				"sha512":        "ee26b0dd4af7e749aa1a8ee3c10ae9923f618980772e473f8819a5d4940e0db27ac185f8a0e1d5f84f88bc887fd67b143732c304cc5fa9ad8e6f57f50028a8ff",
+				"sha512_base64": "7iaw3Ur350mqGo7jwQrpkj9hiYB3Lkc/iBml1JQODbJ6wYX4oOHV+E+IvIh/1nsUNzLDBMxfqa2Ob1f1ACio/w==",
+			},
 		}, combined)
 	})
 
@@ -115,7 +158,7 @@ func TestAccHasher(t *testing.T) {
 			}
 
 			// Test HashDir
-			result, err := hasher.HashDir(tempDir)
+			result, err := hasher.HashDir(tempDir, DirOptions{})
 			assert.NoError(t, err)
 			assert.NotNil(t, result)
 
@@ -128,7 +171,7 @@ func TestAccHasher(t *testing.T) {
 			assert.NotEmpty(t, result.SHA512Base64)
 
 			// Test deterministic behavior - hashing the same directory twice should give same result
-			result2, err := hasher.HashDir(tempDir)
+			result2, err := hasher.HashDir(tempDir, DirOptions{})
 			assert.NoError(t, err)
 			assert.Equal(t, result, result2)
 		})
@@ -170,21 +213,49 @@ func TestAccHasher(t *testing.T) {
 			}
 
 			// Both directories should produce the same hash
-			hash1, err := hasher.HashDir(tempDir1)
+			hash1, err := hasher.HashDir(tempDir1, DirOptions{})
 			assert.NoError(t, err)
 
-			hash2, err := hasher.HashDir(tempDir2)
+			hash2, err := hasher.HashDir(tempDir2, DirOptions{})
 			assert.NoError(t, err)
 
 			assert.Equal(t, hash1, hash2)
 		})
 
+		t.Run("Files", func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			assert.NoError(t, os.MkdirAll(filepath.Join(tempDir, "subdir"), 0755))
+			assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "run.sh"), []byte("#!/bin/sh"), 0755))
+			assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "subdir", "file.txt"), []byte("content"), 0644))
+
+			result, err := hasher.HashDir(tempDir, DirOptions{})
+			assert.NoError(t, err)
+
+			// Directories are only part of the combined hash.
+			assert.Len(t, result.Files, 2)
+			assert.Equal(t, "run.sh", result.Files[0].Path)
+			assert.Equal(t, int64(9), result.Files[0].Size)
+			assert.Equal(t, os.FileMode(0755), result.Files[0].Mode.Perm())
+			assert.Equal(t, "subdir/file.txt", result.Files[1].Path)
+			assert.Equal(t, int64(7), result.Files[1].Size)
+			assert.Len(t, result.Files[1].Digest, 64)
+
+			// Only the digest of the changed file changes.
+			assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "subdir", "file.txt"), []byte("changed"), 0644))
+			changed, err := hasher.HashDir(tempDir, DirOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, result.Files[0], changed.Files[0])
+			assert.NotEqual(t, result.Files[1].Digest, changed.Files[1].Digest)
+		})
+
 		t.Run("Empty_Directory", func(t *testing.T) {
 			t.Parallel()
 
 			tempDir := t.TempDir()
 
-			result, err := hasher.HashDir(tempDir)
+			result, err := hasher.HashDir(tempDir, DirOptions{})
 			assert.NoError(t, err)
 			assert.NotNil(t, result)
 
@@ -193,10 +264,30 @@ func TestAccHasher(t *testing.T) {
 			assert.NotEmpty(t, result.SHA256)
 		})
 
+		t.Run("Concurrency_Independence", func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			for i := range 50 {
+				subDir := filepath.Join(tempDir, fmt.Sprintf("dir%d", i%5))
+				assert.NoError(t, os.MkdirAll(subDir, 0755))
+				assert.NoError(t, os.WriteFile(filepath.Join(subDir, fmt.Sprintf("file%d.txt", i)), []byte(fmt.Sprint(i)), 0644))
+			}
+
+			expected, err := hasher.HashDir(tempDir, DirOptions{Concurrency: 1})
+			assert.NoError(t, err)
+
+			for _, concurrency := range []int{0, 2, 8, 1000} {
+				actual, err := hasher.HashDir(tempDir, DirOptions{Concurrency: concurrency})
+				assert.NoError(t, err)
+				assert.Equal(t, expected, actual, "concurrency %d", concurrency)
+			}
+		})
+
 		t.Run("Nonexistent_Directory", func(t *testing.T) {
 			t.Parallel()
 
-			_, err := hasher.HashDir("/nonexistent/directory")
+			_, err := hasher.HashDir("/nonexistent/directory", DirOptions{})
 			assert.Error(t, err)
 		})
 
@@ -215,10 +306,232 @@ func TestAccHasher(t *testing.T) {
 			err = os.Symlink("regular.txt", symlinkPath)
 			assert.NoError(t, err)
 
-			result, err := hasher.HashDir(tempDir)
+			result, err := hasher.HashDir(tempDir, DirOptions{})
 			assert.NoError(t, err)
 			assert.NotNil(t, result)
 			assert.NotEmpty(t, result.SHA256)
 		})
 	})
+
+	t.Run("HashFiles", func(t *testing.T) {
+		t.Parallel()
+
+		// writeFiles creates the files in a new temporary directory and returns it.
+		writeFiles := func(t *testing.T, files map[string]string) string {
+			t.Helper()
+
+			tempDir := t.TempDir()
+			for relPath, content := range files {
+				fullPath := filepath.Join(tempDir, relPath)
+				assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+				assert.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+			}
+
+			return tempDir
+		}
+
+		files := map[string]string{
+			"main.go":       "package main",
+			"sub/sub.go":    "package sub",
+			"README.md":     "readme",
+			"other/main.go": "package other",
+		}
+
+		t.Run("Only_Listed_Files", func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := writeFiles(t, files)
+			listed := []string{filepath.Join(tempDir, "main.go"), filepath.Join(tempDir, "sub", "sub.go")}
+
+			result, err := hasher.HashFiles(tempDir, listed, FilesOptions{})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, result.SHA256)
+
+			// Unlisted files don't change the hash.
+			assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "README.md"), []byte("changed"), 0644))
+			unchanged, err := hasher.HashFiles(tempDir, listed, FilesOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, result, unchanged)
+
+			// Listed files change the hash.
+			assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "sub", "sub.go"), []byte("package changed"), 0644))
+			changed, err := hasher.HashFiles(tempDir, listed, FilesOptions{})
+			assert.NoError(t, err)
+			assert.NotEqual(t, result, changed)
+		})
+
+		t.Run("Location_And_Order_Independence", func(t *testing.T) {
+			t.Parallel()
+
+			tempDir1 := writeFiles(t, files)
+			tempDir2 := writeFiles(t, files)
+
+			result1, err := hasher.HashFiles(tempDir1, []string{filepath.Join(tempDir1, "main.go"), filepath.Join(tempDir1, "sub", "sub.go")}, FilesOptions{})
+			assert.NoError(t, err)
+			result2, err := hasher.HashFiles(tempDir2, []string{filepath.Join(tempDir2, "sub", "sub.go"), filepath.Join(tempDir2, "main.go")}, FilesOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, result1, result2)
+		})
+
+		t.Run("Options", func(t *testing.T) {
+			t.Parallel()
+
+			tempDir, cacheDir := writeFiles(t, files), t.TempDir()
+			listed := []string{filepath.Join(tempDir, "main.go"), filepath.Join(tempDir, "sub", "sub.go")}
+
+			expected, err := hasher.HashFiles(tempDir, listed, FilesOptions{Algorithms: []string{AlgorithmSHA256}})
+			assert.NoError(t, err)
+
+			for _, concurrency := range []int{1, 2, 8} {
+				actual, err := hasher.HashFiles(tempDir, listed, FilesOptions{Concurrency: concurrency, CacheDir: cacheDir, Algorithms: []string{AlgorithmSHA256}})
+				assert.NoError(t, err)
+				assert.Equal(t, expected, actual)
+			}
+
+			// The digests are cached for the root like the ones of `HashDir`.
+			absRoot, err := filepath.Abs(tempDir)
+			assert.NoError(t, err)
+			cache := openDirCache(cacheDir, absRoot, AlgorithmSHA256)
+			assert.FileExists(t, cache.file)
+		})
+
+		t.Run("Nonexistent_File", func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			_, err := hasher.HashFiles(tempDir, []string{filepath.Join(tempDir, "missing.go")}, FilesOptions{})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("HashDir_Options", func(t *testing.T) {
+		t.Parallel()
+
+		// writeDir creates the files in a new temporary directory and returns it.
+		writeDir := func(t *testing.T, files map[string]string) string {
+			t.Helper()
+
+			tempDir := t.TempDir()
+			for relPath, content := range files {
+				fullPath := filepath.Join(tempDir, relPath)
+				assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+				assert.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+			}
+
+			return tempDir
+		}
+
+		// assertHashedFiles expects the options to hash the same as a directory of only the expected files.
+		assertHashedFiles := func(t *testing.T, files map[string]string, opts DirOptions, expected ...string) {
+			t.Helper()
+
+			expectedFiles := map[string]string{}
+			for _, relPath := range expected {
+				expectedFiles[relPath] = files[relPath]
+			}
+
+			actual, err := hasher.HashDir(writeDir(t, files), opts)
+			assert.NoError(t, err)
+			expectedHash, err := hasher.HashDir(writeDir(t, expectedFiles), DirOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, expectedHash, actual)
+		}
+
+		t.Run("GitIgnore", func(t *testing.T) {
+			t.Parallel()
+
+			assertHashedFiles(t, map[string]string{
+				".gitignore":         "bin/\n*.log\n!keep.log\n/root-only.txt\n",
+				"main.go":            "package main",
+				"app.log":            "log",
+				"keep.log":           "keep",
+				"root-only.txt":      "root",
+				"sub/root-only.txt":  "sub",
+				"bin/tool":           "binary",
+				"sub/.gitignore":     "generated.go\n",
+				"sub/generated.go":   "generated",
+				"sub/x.go":           "package sub",
+				"other/generated.go": "not ignored",
+			}, DirOptions{IgnoreFiles: []string{".gitignore"}},
+				".gitignore", "main.go", "keep.log", "sub/root-only.txt", "sub/.gitignore", "sub/x.go", "other/generated.go")
+		})
+
+		t.Run("DockerIgnore", func(t *testing.T) {
+			t.Parallel()
+
+			assertHashedFiles(t, map[string]string{
+				".dockerignore":        "*.md\nsub/\n",
+				"main.go":              "package main",
+				"README.md":            "readme",
+				"nested/README.md":     "nested",
+				"nested/.dockerignore": "*\n",
+				"sub/x.go":             "package sub",
+			}, DirOptions{IgnoreFiles: []string{".dockerignore"}},
+				".dockerignore", "main.go", "nested/README.md", "nested/.dockerignore")
+		})
+
+		t.Run("Excludes_And_ExcludePaths", func(t *testing.T) {
+			t.Parallel()
+
+			files := map[string]string{
+				".gitignore": "!tmp/\n",
+				"main.go":    "package main",
+				"binary":     "binary",
+				"binary.zip": "archive",
+				"tmp/x":      "tmp",
+			}
+			dir := writeDir(t, files)
+
+			actual, err := hasher.HashDir(dir, DirOptions{
+				IgnoreFiles:  []string{".gitignore"},
+				Excludes:     []string{"tmp/"},
+				ExcludePaths: []string{filepath.Join(dir, "binary"), filepath.Join(dir, "binary.zip"), filepath.Join(dir, "missing")},
+			})
+			assert.NoError(t, err)
+			expected, err := hasher.HashDir(writeDir(t, map[string]string{".gitignore": files[".gitignore"], "main.go": files["main.go"]}), DirOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	})
+}
+
+// BenchmarkHashDir hashes a synthetic tree with more files than the usual descriptor limit.
+func BenchmarkHashDir(b *testing.B) {
+	root := b.TempDir()
+	content := []byte(strings.Repeat("package main\n", 256))
+	for dir := range 100 {
+		dirPath := filepath.Join(root, fmt.Sprintf("pkg%03d", dir))
+		assert.NoError(b, os.MkdirAll(dirPath, 0755))
+
+		for file := range 100 {
+			assert.NoError(b, os.WriteFile(filepath.Join(dirPath, fmt.Sprintf("file%03d.go", file)), content, 0644))
+		}
+	}
+
+	// The files have to be older than the racy window to be cached.
+	modTime := time.Now().Add(-time.Hour)
+	assert.NoError(b, filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		return os.Chtimes(path, modTime, modTime)
+	}))
+
+	hasher := New()
+	for name, opts := range map[string]DirOptions{
+		"Concurrency_1": {Concurrency: 1},
+		"Concurrency_0": {},
+		"Cached":        {CacheDir: b.TempDir()},
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				if _, err := hasher.HashDir(root, opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
 }
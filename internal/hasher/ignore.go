@@ -0,0 +1,113 @@
+package hasher
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// DockerIgnoreFile is the name of the ignore file that only applies in the root directory
+// with patterns relative to it, like the `.dockerignore` of a Docker build context.
+// All other ignore files apply in every directory with the semantics of `.gitignore`.
+const DockerIgnoreFile = ".dockerignore"
+
+// ignoreRule is a pattern of an ignore file or an exclude.
+type ignoreRule struct {
+	// pattern is the doublestar pattern relative to the root of the hashed directory.
+	pattern string
+	// negate re-includes matching files that are excluded by a previous rule.
+	negate bool
+	// dirOnly only matches directories.
+	dirOnly bool
+}
+
+// match reports if the rule matches the slash separated path relative to the root.
+func (r ignoreRule) match(rel string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+
+	return doublestar.MatchUnvalidated(r.pattern, rel)
+}
+
+// parseIgnoreRules parses the lines of an ignore file in the directory `base` (relative to the root).
+// Patterns without a slash match at any level below `base` unless `anchored` is set,
+// which is the case for `.dockerignore` files.
+func parseIgnoreRules(lines []string, base string, anchored bool) []ignoreRule {
+	rules := []ignoreRule{}
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		if line == "" {
+			continue
+		}
+
+		if anchored || strings.Contains(line, "/") {
+			rule.pattern = path.Join(base, path.Clean("/" + line)[1:])
+		} else {
+			rule.pattern = path.Join(base, "**", line)
+		}
+
+		if rule.pattern != "" && doublestar.ValidatePattern(rule.pattern) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// readIgnoreRules reads the rules of the ignore file, which may not exist.
+func readIgnoreRules(file, base string, anchored bool) ([]ignoreRule, error) {
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return parseIgnoreRules(lines, base, anchored), nil
+}
+
+// ignored reports if the path is excluded by the rules, where the last matching rule wins.
+func ignored(rules []ignoreRule, rel string, isDir bool) bool {
+	excluded := false
+	for _, rule := range rules {
+		if rule.match(rel, isDir) {
+			excluded = !rule.negate
+		}
+	}
+
+	return excluded
+}
@@ -0,0 +1,48 @@
+package hasher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccIgnoreRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		lines    []string
+		base     string
+		anchored bool
+		rel      string
+		isDir    bool
+		expected bool
+	}{
+		{name: "Any_Level", lines: []string{"*.log"}, rel: "a/b/c.log", expected: true},
+		{name: "Anchored_Slash", lines: []string{"/build"}, rel: "sub/build", isDir: true, expected: false},
+		{name: "Anchored_Root", lines: []string{"/build"}, rel: "build", isDir: true, expected: true},
+		{name: "Middle_Slash", lines: []string{"docs/*.md"}, rel: "docs/a.md", expected: true},
+		{name: "Middle_Slash_Nested", lines: []string{"docs/*.md"}, rel: "sub/docs/a.md", expected: false},
+		{name: "Dir_Only_File", lines: []string{"bin/"}, rel: "bin", expected: false},
+		{name: "Dir_Only_Dir", lines: []string{"bin/"}, rel: "x/bin", isDir: true, expected: true},
+		{name: "Double_Star", lines: []string{"a/**/z"}, rel: "a/b/c/z", expected: true},
+		{name: "Negation", lines: []string{"*.log", "!keep.log"}, rel: "keep.log", expected: false},
+		{name: "Negation_Order", lines: []string{"!keep.log", "*.log"}, rel: "keep.log", expected: true},
+		{name: "Comment_And_Empty", lines: []string{"# *.log", "", "   "}, rel: "a.log", expected: false},
+		{name: "Escaped", lines: []string{`\#file`, `\!file`}, rel: "!file", expected: true},
+		{name: "Base", lines: []string{"*.gen.go"}, base: "sub", rel: "sub/x/a.gen.go", expected: true},
+		{name: "Base_Outside", lines: []string{"*.gen.go"}, base: "sub", rel: "other/a.gen.go", expected: false},
+		{name: "Docker_Anchored", lines: []string{"*.md"}, anchored: true, rel: "docs/a.md", expected: false},
+		{name: "Docker_Root", lines: []string{"*.md"}, anchored: true, rel: "a.md", expected: true},
+		{name: "Docker_Leading_Slash", lines: []string{"/tmp"}, anchored: true, rel: "tmp", isDir: true, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			rules := parseIgnoreRules(test.lines, test.base, test.anchored)
+			assert.Equal(t, test.expected, ignored(rules, test.rel, test.isDir))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package hasher
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+	"slices"
+)
+
+// multiHash feeds everything written to it into all selected algorithms at once.
+type multiHash struct {
+	io.Writer
+	names  []string
+	hashes []hash.Hash
+}
+
+// newMultiHash creates a new multiHash instance for the algorithms, which default to `DefaultAlgorithms`.
+// Duplicate algorithms are hashed once.
+func newMultiHash(algorithms []string) (*multiHash, error) {
+	if len(algorithms) == 0 {
+		algorithms = DefaultAlgorithms
+	}
+
+	names := slices.Clone(algorithms)
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	m := &multiHash{names: names, hashes: make([]hash.Hash, 0, len(names))}
+	writers := make([]io.Writer, 0, len(names))
+	for _, name := range names {
+		h, err := newAlgorithm(name)
+		if err != nil {
+			return nil, err
+		}
+
+		m.hashes = append(m.hashes, h)
+		writers = append(writers, h)
+	}
+	m.Writer = io.MultiWriter(writers...)
+
+	return m, nil
+}
+
+// Sum returns the hashes of everything written so far.
+func (m *multiHash) Sum() CombinedHash {
+	combinedHash := CombinedHash{Hashes: make(map[string]string, 2*len(m.names))}
+	for i, name := range m.names {
+		sum := m.hashes[i].Sum(nil)
+		combinedHash.Hashes[name] = hex.EncodeToString(sum)
+		combinedHash.Hashes[name+Base64Suffix] = base64.StdEncoding.EncodeToString(sum)
+	}
+
+	combinedHash.MD5 = combinedHash.Hashes[AlgorithmMD5]
+	combinedHash.SHA1 = combinedHash.Hashes[AlgorithmSHA1]
+	combinedHash.SHA256 = combinedHash.Hashes[AlgorithmSHA256]
+	combinedHash.SHA512 = combinedHash.Hashes[AlgorithmSHA512]
+	combinedHash.SHA256Base64 = combinedHash.Hashes[AlgorithmSHA256+Base64Suffix]
+	combinedHash.SHA512Base64 = combinedHash.Hashes[AlgorithmSHA512+Base64Suffix]
+
+	return combinedHash
+}
@@ -1,81 +1,284 @@
 package packager
 
 import (
-	"archive/zip"
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
-// ZIPI is an interface for ZIP type.
-type ZIPI interface {
-	Zip(zipPath string, files map[string]string) error
-}
+// Supported archive formats, which are also used as file extension.
+const (
+	// FormatZIP is a ZIP archive.
+	FormatZIP = "zip"
+	// FormatTarGz is a gzip compressed tar archive.
+	FormatTarGz = "tar.gz"
+	// FormatTarZst is a zstd compressed tar archive.
+	FormatTarZst = "tar.zst"
+)
 
-// Provide ZIP packaging.
-type ZIP struct{}
+var (
+	// ErrDuplicateEntry is returned when multiple files are mapped to the same path inside of the archive.
+	ErrDuplicateEntry = errors.New("duplicate archive entry")
+	// ErrUnknownEntry is returned when a file mode is set for a path that is not part of the archive.
+	ErrUnknownEntry = errors.New("unknown archive entry")
+	// ErrInvalidPattern is returned when a glob pattern of a source or an exclude is malformed.
+	ErrInvalidPattern = errors.New("invalid glob pattern")
+	// ErrNoMatch is returned when a glob pattern of a source doesn't match any file.
+	ErrNoMatch = errors.New("no files match")
+	// ErrSymlinkLoop is returned when a symbolic link points to a directory that contains the link.
+	ErrSymlinkLoop = errors.New("symbolic link loop")
+)
+
+// ModificationTime is the fixed modification time of all archive entries,
+// so the archive does not change if the content stays the same.
+var ModificationTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
 
-// NewZIP creates a new ZIP instance.
-func New() *ZIP {
-	return &ZIP{}
+// Packager is an interface for packaging files into an archive.
+type Packager interface {
+	Package(archivePath string, files map[string]string, fileModes map[string]os.FileMode, excludes []string) error
 }
 
-// Zip given files.
-// `files` is a map of file (including path) to the file path inside of the ZIP.
-// Returns ZIP file SHA256 hash and an error if any.
-func (z ZIP) Zip(zipPath string, files map[string]string) error {
-	if err := os.Remove(zipPath); err != nil && !os.IsNotExist(err) {
-		return err
+// Formats returns all supported archive formats.
+func Formats() []string {
+	return []string{FormatZIP, FormatTarGz, FormatTarZst}
+}
+
+// Packagers returns a packager for every supported archive format.
+func Packagers() map[string]Packager {
+	return map[string]Packager{
+		FormatZIP:    NewZIP(),
+		FormatTarGz:  NewTarGz(),
+		FormatTarZst: NewTarZst(),
 	}
+}
 
-	archive, err := os.Create(zipPath)
-	if err != nil {
-		return err
+// entry is a file to add to an archive.
+type entry struct {
+	// source is the path of the file on the file system.
+	source string
+	// name is the path of the file inside of the archive.
+	name string
+	// mode is the file mode inside of the archive.
+	mode os.FileMode
+	// size is the size of the file.
+	size int64
+}
+
+// create removes an existing archive and creates a new one.
+func create(archivePath string) (*os.File, error) {
+	if err := os.Remove(archivePath); err != nil && !os.IsNotExist(err) {
+		return nil, err
 	}
 
-	defer archive.Close()
+	return os.Create(archivePath)
+}
 
-	zipWriter := zip.NewWriter(archive)
+// root is a file or directory to walk with the path of it inside of the archive.
+type root struct {
+	source      string
+	destination string
+	// explicit is set if the source is listed without a glob pattern, so it is never excluded itself.
+	explicit bool
+}
 
-	defer zipWriter.Close()
+// isGlob reports if the source is a glob pattern instead of a path.
+func isGlob(source string) bool {
+	return strings.ContainsAny(source, "*?[{")
+}
 
+// resolve expands the glob patterns of the sources into the matched files.
+// A matched file keeps its path relative to the static part of the pattern below the destination
+// (e.g. `templates/**/*.tmpl` maps `templates/mail/welcome.tmpl` to `<destination>/mail/welcome.tmpl`).
+func resolve(files map[string]string) ([]root, error) {
+	roots := []root{}
 	for source, destination := range files {
-		err := filepath.WalkDir(source, func(path string, d os.DirEntry, err error) error {
+		if !isGlob(source) {
+			roots = append(roots, root{source: source, destination: destination, explicit: true})
+
+			continue
+		}
+
+		if !doublestar.ValidatePathPattern(filepath.ToSlash(source)) {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidPattern, source)
+		}
+
+		// Symbolic links are followed, so linked files and directories are matched like regular ones.
+		matches, err := doublestar.FilepathGlob(source, doublestar.WithFilesOnly(), doublestar.WithFailOnIOErrors())
+		if err != nil {
+			return nil, err
+		} else if len(matches) == 0 {
+			return nil, fmt.Errorf("%w: '%s'", ErrNoMatch, source)
+		}
+
+		base, _ := doublestar.SplitPattern(filepath.ToSlash(filepath.Clean(source)))
+		for _, match := range matches {
+			relativePath, err := filepath.Rel(filepath.FromSlash(base), match)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
-			if !d.IsDir() {
-				relativePath, err := filepath.Rel(source, path)
-				if err != nil {
-					return err
-				}
+			roots = append(roots, root{source: match, destination: filepath.Join(destination, relativePath)})
+		}
+	}
+
+	return roots, nil
+}
+
+// excluded reports if the path inside of the archive or one of its parent directories
+// matches any of the exclude patterns.
+func excluded(name string, excludes []string) bool {
+	for ; name != "." && name != "/" && name != ""; name = path.Dir(name) {
+		for _, exclude := range excludes {
+			if doublestar.MatchUnvalidated(exclude, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
 
-				zipEntryPath := filepath.Join(destination, relativePath)
+// collect walks the given files and directories and returns all files sorted by their archive path.
+// Sources can be glob patterns (e.g. `templates/**/*.tmpl`), see `resolve`.
+// Files and directories whose archive path matches one of the `excludes` glob patterns are skipped,
+// except sources that are listed explicitly without a pattern.
+// Symbolic links to directories are followed like for glob patterns.
+// The file mode of an entry is the permission of the file, unless it is overridden by `fileModes`.
+func collect(files map[string]string, fileModes map[string]os.FileMode, excludes []string) ([]entry, error) {
+	for _, exclude := range excludes {
+		if !doublestar.ValidatePattern(exclude) {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidPattern, exclude)
+		}
+	}
 
-				f, err := os.Open(path)
-				if err != nil {
-					return err
-				}
+	roots, err := resolve(files)
+	if err != nil {
+		return nil, err
+	}
 
-				defer f.Close()
+	c := &collector{fileModes: fileModes, excludes: excludes, names: map[string]string{}}
+	for _, r := range roots {
+		if err := c.walk(r, nil); err != nil {
+			return nil, err
+		}
+	}
 
-				writer, err := zipWriter.Create(zipEntryPath)
-				if err != nil {
-					return err
-				}
+	for name := range fileModes {
+		if _, ok := c.names[name]; !ok {
+			return nil, fmt.Errorf("%w: file mode is set for '%s'", ErrUnknownEntry, name)
+		}
+	}
 
-				if _, err := io.Copy(writer, f); err != nil {
-					return err
-				}
+	sort.Slice(c.entries, func(i, j int) bool {
+		return c.entries[i].name < c.entries[j].name
+	})
+
+	return c.entries, nil
+}
+
+// collector holds the state of `collect` while walking the roots.
+type collector struct {
+	fileModes map[string]os.FileMode
+	excludes  []string
+	entries   []entry
+	// names maps the archive paths to the path of their file on the file system.
+	names map[string]string
+}
+
+// walk adds the files of the root to the entries and follows symbolic links to directories.
+// The `parents` are the resolved directories of the followed links, which must not be walked again.
+func (c *collector) walk(r root, parents []string) error {
+	return filepath.WalkDir(r.source, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(r.source, path)
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(filepath.Join(r.destination, relativePath))
+		if !(r.explicit && path == r.source) && excluded(name, c.excludes) {
+			if d.IsDir() {
+				return filepath.SkipDir
 			}
 
 			return nil
-		})
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		// Symbolic links are archived with the content and mode of their target.
+		info, err := os.Stat(path)
 		if err != nil {
 			return err
 		}
+
+		if info.IsDir() {
+			return c.walkLink(path, name, parents)
+		}
+
+		if previous, ok := c.names[name]; ok {
+			return fmt.Errorf("%w: '%s' and '%s' are both mapped to '%s'", ErrDuplicateEntry, previous, path, name)
+		}
+
+		mode, ok := c.fileModes[name]
+		if !ok {
+			mode = info.Mode()
+		}
+
+		c.names[name] = path
+		c.entries = append(c.entries, entry{source: path, name: name, mode: mode.Perm(), size: info.Size()})
+
+		return nil
+	})
+}
+
+// walkLink walks the directory of the symbolic link at `path` below its archive path `name`.
+// Links to a directory that contains the link or to an already followed directory are a loop.
+func (c *collector) walkLink(path, name string, parents []string) error {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	location, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range append(slices.Clone(parents), location) {
+		if relativePath, err := filepath.Rel(target, dir); err == nil && relativePath != ".." &&
+			!strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("%w: '%s' points to '%s'", ErrSymlinkLoop, path, target)
+		}
+	}
+
+	return c.walk(root{source: target, destination: name}, append(slices.Clone(parents), target))
+}
+
+// copyFile copies the content of the file at `path` to `writer`.
+func copyFile(writer io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	_, err = io.Copy(writer, f)
+
+	return err
 }
@@ -1,15 +1,19 @@
 package packager
 
-import "github.com/stretchr/testify/mock"
+import (
+	"os"
 
-// MockZIP is a mock implementation of ZIP.
-type MockZIP struct {
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPackager is a mock implementation of Packager.
+type MockPackager struct {
 	mock.Mock
 }
 
-// Zip is a mocked method.
-func (m *MockZIP) Zip(zipPath string, files map[string]string) error {
-	args := m.Called(zipPath, files)
+// Package is a mocked method.
+func (m *MockPackager) Package(archivePath string, files map[string]string, fileModes map[string]os.FileMode, excludes []string) error {
+	args := m.Called(archivePath, files, fileModes, excludes)
 
 	return args.Error(0)
 }
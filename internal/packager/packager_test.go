@@ -1,33 +1,228 @@
 package packager
 
 import (
+	"flag"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// update rewrites the golden files instead of comparing against them.
+var update = flag.Bool("update", false, "update golden files")
+
 func TestAccInterfaceSatisfaction(t *testing.T) {
 	t.Parallel()
 
-	var _ ZIPI = &ZIP{}
-	var _ ZIPI = &MockZIP{}
+	var _ Packager = &ZIP{}
+	var _ Packager = &TarGz{}
+	var _ Packager = &TarZst{}
+	var _ Packager = &MockPackager{}
+}
+
+// goldenInput returns the files and file modes used for the golden files.
+func goldenInput() (map[string]string, map[string]os.FileMode) {
+	files := map[string]string{
+		"testdata/input/static":    "www/static",
+		"testdata/input/README.md": "README.md",
+	}
+	// The permissions of checked out files depend on the umask, so they are fixed for the golden files.
+	fileModes := map[string]os.FileMode{
+		"README.md":                0644,
+		"www/static/app.js":        0644,
+		"www/static/css/style.css": 0600,
+	}
+
+	return files, fileModes
+}
+
+// assertGolden compares the archive with the golden file, which is rewritten with `-update`.
+func assertGolden(t *testing.T, archivePath, goldenPath string) {
+	t.Helper()
+
+	actual, err := os.ReadFile(archivePath)
+	assert.NoError(t, err)
+
+	if *update {
+		assert.NoError(t, os.WriteFile(goldenPath, actual, 0644))
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+// assertRepeatable packages the files twice and expects byte-identical archives.
+func assertRepeatable(t *testing.T, packager Packager, files map[string]string, fileModes map[string]os.FileMode) {
+	t.Helper()
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first")
+	second := filepath.Join(dir, "second")
+
+	assert.NoError(t, packager.Package(first, files, fileModes, nil))
+	assert.NoError(t, packager.Package(second, files, fileModes, nil))
+
+	firstContent, err := os.ReadFile(first)
+	assert.NoError(t, err)
+	secondContent, err := os.ReadFile(second)
+	assert.NoError(t, err)
+	assert.Equal(t, firstContent, secondContent)
 }
 
-func TestAccZIPZip(t *testing.T) {
+func TestAccCollect(t *testing.T) {
 	t.Parallel()
 
-	t.Cleanup(func() {
-		os.Remove("test.zip")
+	source := t.TempDir()
+	for path, content := range map[string]string{
+		"templates/mail/welcome.tmpl":   "welcome",
+		"templates/web/deep/page.tmpl":  "page",
+		"templates/README.md":           "readme",
+		"templates/.DS_Store":           "",
+		"shared/base.tmpl":              "base",
+		"static/app.js":                 "app",
+		"static/.app.js.swp":            "",
+		"static/.DS_Store":              "",
+		"static/vendor/lib.js":          "lib",
+		"static/node_modules/dep/x.js":  "x",
+		"static/node_modules/dep/y.tmp": "y",
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(source, filepath.Dir(path)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(source, path), []byte(content), 0644))
+	}
+
+	// A symbolic link to a file and one to a directory outside of the matched directory.
+	assert.NoError(t, os.MkdirAll(filepath.Join(source, "templates", "links"), 0755))
+	assert.NoError(t, os.Symlink(filepath.Join(source, "templates", "mail", "welcome.tmpl"), filepath.Join(source, "templates", "links", "linked.tmpl")))
+	assert.NoError(t, os.Symlink(filepath.Join(source, "shared"), filepath.Join(source, "templates", "shared")))
+
+	names := func(entries []entry) []string {
+		names := []string{}
+		for _, e := range entries {
+			names = append(names, e.name)
+		}
+
+		return names
+	}
+
+	t.Run("Glob", func(t *testing.T) {
+		t.Parallel()
+
+		entries, err := collect(map[string]string{
+			filepath.Join(source, "templates", "**", "*.tmpl"): "tmpl",
+		}, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"tmpl/links/linked.tmpl",
+			"tmpl/mail/welcome.tmpl",
+			"tmpl/shared/base.tmpl",
+			"tmpl/web/deep/page.tmpl",
+		}, names(entries))
+
+		// Symbolic links are archived with the content of their target.
+		assert.Equal(t, int64(len("welcome")), entries[0].size)
 	})
 
-	zip := ZIP{}
-	err := zip.Zip("test.zip", map[string]string{
-		"packager.go":      "packager.go",
-		"packager_mock.go": "a/packager_mock.go",
-		"packager_test.go": "b/packager_test.go",
-		"../packager":      "c/packager",
+	t.Run("Directory_Symlink", func(t *testing.T) {
+		t.Parallel()
+
+		entries, err := collect(map[string]string{
+			filepath.Join(source, "templates"): "tmpl",
+		}, nil, []string{"**/.DS_Store", "**/deep/**"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"tmpl/README.md",
+			"tmpl/links/linked.tmpl",
+			"tmpl/mail/welcome.tmpl",
+			"tmpl/shared/base.tmpl",
+		}, names(entries))
+
+		// Linked directories are archived with the content of their files like for glob patterns.
+		assert.Equal(t, filepath.Join(source, "shared", "base.tmpl"), entries[3].source)
+		assert.Equal(t, int64(len("base")), entries[3].size)
 	})
 
-	assert.NoError(t, err)
+	t.Run("Directory_Symlink_Loop", func(t *testing.T) {
+		t.Parallel()
+
+		loop := t.TempDir()
+		assert.NoError(t, os.MkdirAll(filepath.Join(loop, "a", "b"), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(loop, "a", "b", "file"), []byte("file"), 0644))
+		assert.NoError(t, os.Symlink(filepath.Join(loop, "a"), filepath.Join(loop, "a", "b", "parent")))
+
+		_, err := collect(map[string]string{filepath.Join(loop, "a"): "."}, nil, nil)
+		assert.ErrorIs(t, err, ErrSymlinkLoop)
+
+		// Links between sibling directories are a loop once followed back.
+		siblings := t.TempDir()
+		assert.NoError(t, os.MkdirAll(filepath.Join(siblings, "x"), 0755))
+		assert.NoError(t, os.MkdirAll(filepath.Join(siblings, "y"), 0755))
+		assert.NoError(t, os.Symlink(filepath.Join(siblings, "y"), filepath.Join(siblings, "x", "y")))
+		assert.NoError(t, os.Symlink(filepath.Join(siblings, "x"), filepath.Join(siblings, "y", "x")))
+
+		_, err = collect(map[string]string{filepath.Join(siblings, "x"): "."}, nil, nil)
+		assert.ErrorIs(t, err, ErrSymlinkLoop)
+	})
+
+	t.Run("Glob_Root", func(t *testing.T) {
+		t.Parallel()
+
+		entries, err := collect(map[string]string{
+			filepath.Join(source, "static", "*.js"): ".",
+		}, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"app.js"}, names(entries))
+	})
+
+	t.Run("Excludes", func(t *testing.T) {
+		t.Parallel()
+
+		entries, err := collect(map[string]string{
+			filepath.Join(source, "static"): "www",
+		}, nil, []string{"**/.DS_Store", "**/*.swp", "www/node_modules"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"www/app.js", "www/vendor/lib.js"}, names(entries))
+	})
+
+	t.Run("Excludes_Glob", func(t *testing.T) {
+		t.Parallel()
+
+		entries, err := collect(map[string]string{
+			filepath.Join(source, "templates", "**"): "tmpl",
+		}, nil, []string{"**/.DS_Store", "**/deep/**", "tmpl/shared"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"tmpl/README.md",
+			"tmpl/links/linked.tmpl",
+			"tmpl/mail/welcome.tmpl",
+		}, names(entries))
+	})
+
+	t.Run("Excludes_Explicit", func(t *testing.T) {
+		t.Parallel()
+
+		entries, err := collect(map[string]string{
+			filepath.Join(source, "static", ".DS_Store"): ".DS_Store",
+		}, nil, []string{"**/.DS_Store"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{".DS_Store"}, names(entries))
+	})
+
+	t.Run("Invalid_Pattern", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := collect(map[string]string{filepath.Join(source, "static"): "."}, nil, []string{"[a-"})
+		assert.ErrorIs(t, err, ErrInvalidPattern)
+
+		_, err = collect(map[string]string{filepath.Join(source, "[a-"): "."}, nil, nil)
+		assert.ErrorIs(t, err, ErrInvalidPattern)
+	})
+
+	t.Run("No_Match", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := collect(map[string]string{filepath.Join(source, "**", "*.nothing"): "."}, nil, nil)
+		assert.ErrorIs(t, err, ErrNoMatch)
+	})
 }
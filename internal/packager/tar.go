@@ -0,0 +1,97 @@
+package packager
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Provide gzip compressed tar packaging.
+type TarGz struct{}
+
+// NewTarGz creates a new TarGz instance.
+func NewTarGz() *TarGz {
+	return &TarGz{}
+}
+
+// Package writes the given files into a gzip compressed tar archive.
+// See `ZIP.Package` for the meaning of `files`, `fileModes` and `excludes`.
+func (t TarGz) Package(archivePath string, files map[string]string, fileModes map[string]os.FileMode, excludes []string) error {
+	return packageTar(archivePath, files, fileModes, excludes, func(w io.Writer) (io.WriteCloser, error) {
+		// The gzip header is left empty (no name, no modification time) to stay deterministic.
+		return gzip.NewWriterLevel(w, gzip.BestCompression)
+	})
+}
+
+// Provide zstd compressed tar packaging.
+type TarZst struct{}
+
+// NewTarZst creates a new TarZst instance.
+func NewTarZst() *TarZst {
+	return &TarZst{}
+}
+
+// Package writes the given files into a zstd compressed tar archive.
+// See `ZIP.Package` for the meaning of `files`, `fileModes` and `excludes`.
+func (t TarZst) Package(archivePath string, files map[string]string, fileModes map[string]os.FileMode, excludes []string) error {
+	return packageTar(archivePath, files, fileModes, excludes, func(w io.Writer) (io.WriteCloser, error) {
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	})
+}
+
+// packageTar writes the given files into a tar archive compressed by the writer of `compress`.
+// Entries are written in sorted order with fixed headers, so the same files always result in the same archive.
+func packageTar(
+	archivePath string,
+	files map[string]string,
+	fileModes map[string]os.FileMode,
+	excludes []string,
+	compress func(w io.Writer) (io.WriteCloser, error),
+) error {
+	entries, err := collect(files, fileModes, excludes)
+	if err != nil {
+		return err
+	}
+
+	archive, err := create(archivePath)
+	if err != nil {
+		return err
+	}
+
+	defer archive.Close()
+
+	compressor, err := compress(archive)
+	if err != nil {
+		return err
+	}
+
+	tarWriter := tar.NewWriter(compressor)
+
+	for _, e := range entries {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     e.name,
+			Mode:     int64(e.mode),
+			Size:     e.size,
+			ModTime:  ModificationTime,
+			Format:   tar.FormatPAX,
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if err := copyFile(tarWriter, e.source); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+
+	return compressor.Close()
+}
@@ -0,0 +1,108 @@
+package packager
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccTarPackage(t *testing.T) {
+	t.Parallel()
+
+	files, fileModes := goldenInput()
+
+	// readTar returns the content and mode of every tar entry.
+	readTar := func(t *testing.T, reader io.Reader) ([]string, map[string]string, map[string]os.FileMode) {
+		t.Helper()
+
+		names := []string{}
+		contents := map[string]string{}
+		modes := map[string]os.FileMode{}
+
+		tarReader := tar.NewReader(reader)
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, header.ModTime.Equal(ModificationTime))
+
+			content, err := io.ReadAll(tarReader)
+			assert.NoError(t, err)
+
+			names = append(names, header.Name)
+			contents[header.Name] = string(content)
+			modes[header.Name] = os.FileMode(header.Mode)
+		}
+
+		return names, contents, modes
+	}
+
+	expectedNames := []string{"README.md", "www/static/app.js", "www/static/css/style.css"}
+
+	t.Run("TarGz", func(t *testing.T) {
+		t.Parallel()
+
+		archivePath := filepath.Join(t.TempDir(), "golden.tar.gz")
+		assert.NoError(t, TarGz{}.Package(archivePath, files, fileModes, nil))
+
+		assertGolden(t, archivePath, filepath.Join("testdata", "golden.tar.gz"))
+		assertRepeatable(t, TarGz{}, files, fileModes)
+
+		f, err := os.Open(archivePath)
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			f.Close()
+		})
+
+		gzipReader, err := gzip.NewReader(f)
+		assert.NoError(t, err)
+
+		names, contents, modes := readTar(t, gzipReader)
+		assert.Equal(t, expectedNames, names)
+		assert.Equal(t, "console.log(\"hello\");\n", contents["www/static/app.js"])
+		assert.Equal(t, os.FileMode(0600), modes["www/static/css/style.css"])
+	})
+
+	t.Run("TarZst", func(t *testing.T) {
+		t.Parallel()
+
+		archivePath := filepath.Join(t.TempDir(), "golden.tar.zst")
+		assert.NoError(t, TarZst{}.Package(archivePath, files, fileModes, nil))
+
+		assertGolden(t, archivePath, filepath.Join("testdata", "golden.tar.zst"))
+		assertRepeatable(t, TarZst{}, files, fileModes)
+
+		f, err := os.Open(archivePath)
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			f.Close()
+		})
+
+		zstdReader, err := zstd.NewReader(f)
+		assert.NoError(t, err)
+		t.Cleanup(zstdReader.Close)
+
+		names, contents, modes := readTar(t, zstdReader)
+		assert.Equal(t, expectedNames, names)
+		assert.Equal(t, "console.log(\"hello\");\n", contents["www/static/app.js"])
+		assert.Equal(t, os.FileMode(0644), modes["README.md"])
+	})
+
+	t.Run("Unknown_Entry", func(t *testing.T) {
+		t.Parallel()
+
+		err := TarGz{}.Package(filepath.Join(t.TempDir(), "unknown.tar.gz"), files, map[string]os.FileMode{
+			"does_not_exist": 0644,
+		}, nil)
+		assert.ErrorIs(t, err, ErrUnknownEntry)
+	})
+}
@@ -0,0 +1,57 @@
+package packager
+
+import (
+	"archive/zip"
+	"os"
+)
+
+// Provide ZIP packaging.
+type ZIP struct{}
+
+// NewZIP creates a new ZIP instance.
+func NewZIP() *ZIP {
+	return &ZIP{}
+}
+
+// Package zips the given files.
+// `files` is a map of file (including path) to the file path inside of the ZIP.
+// `fileModes` overrides the permissions of files inside of the ZIP by their path inside of the ZIP,
+// all other files keep the permissions they have on the file system.
+// Files can be glob patterns (e.g. `templates/**/*.tmpl`) and `excludes` are glob patterns of paths
+// inside of the ZIP to skip (e.g. `**/.DS_Store`).
+// Entries are written in sorted order with fixed headers, so the same files always result in the same ZIP.
+func (z ZIP) Package(zipPath string, files map[string]string, fileModes map[string]os.FileMode, excludes []string) error {
+	entries, err := collect(files, fileModes, excludes)
+	if err != nil {
+		return err
+	}
+
+	archive, err := create(zipPath)
+	if err != nil {
+		return err
+	}
+
+	defer archive.Close()
+
+	zipWriter := zip.NewWriter(archive)
+
+	for _, e := range entries {
+		header := &zip.FileHeader{
+			Name:     e.name,
+			Method:   zip.Deflate,
+			Modified: ModificationTime,
+		}
+		header.SetMode(e.mode)
+
+		writer, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		if err := copyFile(writer, e.source); err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
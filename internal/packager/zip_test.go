@@ -0,0 +1,152 @@
+package packager
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccZIPPackage(t *testing.T) {
+	t.Parallel()
+
+	t.Cleanup(func() {
+		os.Remove("test.zip")
+	})
+
+	zip := ZIP{}
+	err := zip.Package("test.zip", map[string]string{
+		"packager.go":      "packager.go",
+		"packager_mock.go": "a/packager_mock.go",
+		"packager_test.go": "b/packager_test.go",
+		"../packager":      "c/packager",
+	}, nil, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestAccZIPPackageDeterministic(t *testing.T) {
+	t.Parallel()
+
+	files, fileModes := goldenInput()
+
+	t.Run("Golden", func(t *testing.T) {
+		t.Parallel()
+
+		zipPath := filepath.Join(t.TempDir(), "golden.zip")
+		err := ZIP{}.Package(zipPath, files, fileModes, nil)
+		assert.NoError(t, err)
+
+		assertGolden(t, zipPath, filepath.Join("testdata", "golden.zip"))
+	})
+
+	t.Run("Repeated", func(t *testing.T) {
+		t.Parallel()
+
+		assertRepeatable(t, ZIP{}, files, fileModes)
+	})
+
+	t.Run("Sorted_Entries", func(t *testing.T) {
+		t.Parallel()
+
+		zipPath := filepath.Join(t.TempDir(), "sorted.zip")
+		assert.NoError(t, ZIP{}.Package(zipPath, files, fileModes, nil))
+
+		reader, err := zip.OpenReader(zipPath)
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			reader.Close()
+		})
+
+		names := []string{}
+		for _, f := range reader.File {
+			names = append(names, f.Name)
+			assert.True(t, f.Modified.Equal(ModificationTime))
+		}
+
+		assert.Equal(t, []string{
+			"README.md",
+			"www/static/app.js",
+			"www/static/css/style.css",
+		}, names)
+	})
+
+	t.Run("Duplicate_Entry", func(t *testing.T) {
+		t.Parallel()
+
+		err := ZIP{}.Package(filepath.Join(t.TempDir(), "duplicate.zip"), map[string]string{
+			"testdata/input/README.md":     "README.md",
+			"testdata/input/static/app.js": "README.md",
+		}, nil, nil)
+		assert.ErrorIs(t, err, ErrDuplicateEntry)
+	})
+}
+
+func TestAccZIPPackageFileModes(t *testing.T) {
+	t.Parallel()
+
+	source := t.TempDir()
+	binary := filepath.Join(source, "bootstrap")
+	assert.NoError(t, os.WriteFile(binary, []byte("binary"), 0755))
+	resource := filepath.Join(source, "config.json")
+	assert.NoError(t, os.WriteFile(resource, []byte("{}"), 0600))
+	assert.NoError(t, os.Chmod(resource, 0600))
+	assert.NoError(t, os.Symlink("bootstrap", filepath.Join(source, "link")))
+
+	modes := func(t *testing.T, zipPath string) map[string]os.FileMode {
+		t.Helper()
+
+		reader, err := zip.OpenReader(zipPath)
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			reader.Close()
+		})
+
+		modes := map[string]os.FileMode{}
+		for _, f := range reader.File {
+			modes[f.Name] = f.Mode()
+		}
+
+		return modes
+	}
+
+	t.Run("Preserved", func(t *testing.T) {
+		t.Parallel()
+
+		zipPath := filepath.Join(t.TempDir(), "preserved.zip")
+		err := ZIP{}.Package(zipPath, map[string]string{source: "."}, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]os.FileMode{
+			"bootstrap":   0755,
+			"config.json": 0600,
+			"link":        0755,
+		}, modes(t, zipPath))
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		t.Parallel()
+
+		zipPath := filepath.Join(t.TempDir(), "override.zip")
+		err := ZIP{}.Package(zipPath, map[string]string{source: "app"}, map[string]os.FileMode{
+			"app/bootstrap":   0700,
+			"app/config.json": 0644,
+		}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]os.FileMode{
+			"app/bootstrap":   0700,
+			"app/config.json": 0644,
+			"app/link":        0755,
+		}, modes(t, zipPath))
+	})
+
+	t.Run("Unknown_Entry", func(t *testing.T) {
+		t.Parallel()
+
+		err := ZIP{}.Package(filepath.Join(t.TempDir(), "unknown.zip"), map[string]string{source: "."}, map[string]os.FileMode{
+			"does_not_exist": 0644,
+		}, nil)
+		assert.ErrorIs(t, err, ErrUnknownEntry)
+	})
+}
@@ -0,0 +1,569 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/stevencyb/gopackager/internal/hasher"
+	"github.com/stevencyb/gopackager/internal/packager"
+)
+
+// BinaryResourceModel is the model for the binary resource.
+type BinaryResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	BuildModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// artifactPaths returns the paths of all files created by the build.
+func (b *BinaryResourceModel) artifactPaths() []string {
+	paths := []string{}
+	if outputPath := b.OutputPath.ValueString(); outputPath != "" {
+		paths = append(paths, outputPath)
+	}
+
+	// The binary stays next to the archive.
+	if archiveFormat := b.ArchiveFormat.ValueString(); archiveFormat != "" && strings.HasSuffix(b.OutputPath.ValueString(), "."+archiveFormat) {
+		paths = append(paths, strings.TrimSuffix(b.OutputPath.ValueString(), "."+archiveFormat))
+	}
+
+	if manifestPath := b.SourceManifestFile.ValueString(); manifestPath != "" {
+		paths = append(paths, manifestPath)
+	}
+
+	return paths
+}
+
+// BinaryResource is the resource for a compiled binary.
+type BinaryResource struct {
+	defaults *ProviderDefaults
+}
+
+// NewBinaryResource creates a new resource instance.
+func NewBinaryResource() resource.Resource {
+	return &BinaryResource{}
+}
+
+// Sets the resource metadata.
+func (b *BinaryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_binary"
+}
+
+// Sets the resource schema.
+func (b *BinaryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	description := `Compiles GoLang source code into a binary executable and optionally creates an archive (ZIP, tar.gz or tar.zst) with additional files.` +
+		` In contrast to the ` + "`gopackager_compile`" + ` data source, the source hash is computed at plan time` +
+		` and the binary is only built on create or when the source hash or the build settings change.` +
+		` The artifact is deleted on destroy. This resource requires GoLang to be installed on the system.`
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: description,
+		Description:         description,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the resource, which is the output path.",
+				Computed:            true,
+			},
+			// Required input
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Path to the main file.",
+				Required:            true,
+			},
+			"destination": schema.StringAttribute{
+				MarkdownDescription: "Path for the compiled binary. Relative paths are resolved against the `artifact_dir` of the provider if set.",
+				Required:            true,
+			},
+			"goos": schema.StringAttribute{
+				MarkdownDescription: "GOOS for the compiled binary. Defaults to the `goos` of the provider, one of both must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"goarch": schema.StringAttribute{
+				MarkdownDescription: "GOARCH for the compiled binary. Defaults to the `goarch` of the provider, one of both must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			// Output input
+			"archive_format": schema.StringAttribute{
+				MarkdownDescription: "Archive the compiled binary and additional resources. Supported formats are `zip`, `tar.gz` and `tar.zst`. " +
+					"The format is appended as extension to the destination.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(packager.Formats()...),
+				},
+			},
+			"zip_resources": schema.MapAttribute{
+				MarkdownDescription: "Additional resources to include in the archive. The binary is automatically included an copied to the root of the archive. " +
+					"Sources can be glob patterns (e.g. `templates/**/*.tmpl`), which keep the path of matched files relative to the part before the first wildcard below the archive path.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"zip_file_modes": schema.MapAttribute{
+				MarkdownDescription: "Overwrite the permissions of files inside of the archive by their path inside of the archive (e.g. `bootstrap = \"0755\"`). " +
+					"Files without an entry keep the permissions they have on the file system.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(fileModePattern, "must be an octal file permission like \"0755\""),
+					),
+				},
+			},
+			"zip_excludes": schema.ListAttribute{
+				MarkdownDescription: "Glob patterns of paths inside of the archive to exclude (e.g. `**/.DS_Store` or `**/*_test.go`). " +
+					"A pattern matching a directory excludes all files inside of it. Files listed in `zip_resources` without a pattern are always included.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"base_path": schema.StringAttribute{
+				MarkdownDescription: "Overwrite the base path to watch that is by default the source directory.",
+				Optional:            true,
+			},
+			"hash_mode": schema.StringAttribute{
+				MarkdownDescription: "Files of the base path that are hashed for `output_*`. " +
+					"`dir` (default) hashes all files. `deps` hashes only the files the binary is built from according to `go list -deps`: " +
+					"the Go and embedded files of the packages of the main module and of modules replaced by a local directory as well as their `go.mod` and `go.sum`, " +
+					"so changes to unrelated files no longer change the hashes.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(hashModeDir, hashModeDeps),
+				},
+			},
+			"hash_excludes": schema.ListAttribute{
+				MarkdownDescription: "Patterns in the format of `.gitignore` relative to the base path of files to exclude from the hashes with `hash_mode = \"dir\"` (e.g. `dist/` or `*.log`). " +
+					"Unlike the patterns of ignore files they can't be negated. The `destination` and its archives are always excluded.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"hash_ignore_files": schema.ListAttribute{
+				MarkdownDescription: "Names of ignore files whose patterns exclude files from the hashes with `hash_mode = \"dir\"`, defaults to `[\".gitignore\", \".dockerignore\"]`. " +
+					"Ignore files apply to their directory like a `.gitignore`, except for `.dockerignore` that only applies in the base path with patterns relative to it. " +
+					"Set to `[]` to hash ignored files as well.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"hash_algorithms": schema.ListAttribute{
+				MarkdownDescription: "Algorithms of the hashes in `output_hashes` and `artifact_hashes`, defaults to `[\"md5\", \"sha1\", \"sha256\", \"sha512\"]`. " +
+					"Supported are `" + strings.Join(hasher.Algorithms(), "`, `") + "`. " +
+					"Only the selected algorithms are computed, so the `output_*` and `artifact_*` attributes of other algorithms are null. " +
+					"The source files are hashed with `sha512` if selected, otherwise with the strongest selected of `sha3_512`, `blake2b_512`, `sha3_256`, `blake2b_256` and `sha256` or else with `sha512`, and their digests are combined with each selected algorithm.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(hasher.Algorithms()...)),
+				},
+			},
+			"source_manifest_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Set `source_manifest` to the hashed source files, so that `terraform plan` shows which files changed.",
+				Optional:            true,
+			},
+			"source_manifest_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a JSON file the hashed source files are written to after the build, relative to the working directory. The file itself is never hashed.",
+				Optional:            true,
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Build tags passed to `go build -tags`. Changing the tags changes the output hashes.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"cgo_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Set `CGO_ENABLED` for the build. If not set, the Go default applies regardless of the host environment.",
+				Optional:            true,
+			},
+			"env": schema.MapAttribute{
+				MarkdownDescription: "Additional environment variables for the build (e.g. `GOAMD64`). " +
+					"GO* variables of the host are not passed to the build unless they only configure paths, caches, proxies or private modules.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"reproducible": schema.BoolAttribute{
+				MarkdownDescription: "Build a byte-identical binary for the same source on every machine. " +
+					"Applies `-trimpath`, `-buildvcs=false` and an empty build ID and ignores `GOFLAGS` and the go env file of the host.",
+				Optional: true,
+			},
+			"build_flags": schema.ListAttribute{
+				MarkdownDescription: "Additional flags passed to `go build` (e.g. `-v`), which replace the `build_flags` of the provider.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			// Output
+			"output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Output path for the compiled binary or archive.",
+			},
+			"output_md5": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MD5 hash of the source files.",
+			},
+			"output_sha1": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA1 hash of the source files.",
+			},
+			"output_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 hash of the source files.",
+			},
+			"output_sha512": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA512 hash of the source files.",
+			},
+			"output_sha256_base64": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Base64 encoded SHA256 hash of the source files.",
+			},
+			"output_sha512_base64": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Base64 encoded SHA512 hash of the source files.",
+			},
+			"output_hashes": schema.MapAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hashes of the source files by algorithm of `hash_algorithms` as hex and by algorithm with the suffix `_base64` base64 encoded (e.g. `crc32c_base64`).",
+				ElementType:         types.StringType,
+			},
+			"source_manifest": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hashed source files by their path relative to the base path if `source_manifest_enabled` is set.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"digest": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Hash of the path and content of the file or the target of the symlink with the algorithm the source files are hashed with (see `hash_algorithms`).",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size of the file in bytes.",
+						},
+						"mode": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Permissions of the file like `0644`.",
+						},
+					},
+				},
+			},
+			// Artifact output
+			"artifact_md5": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MD5 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha1": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA1 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha512": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA512 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha256_base64": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Base64 encoded SHA256 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha512_base64": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Base64 encoded SHA512 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_hashes": schema.MapAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hashes of the compiled binary or archive at `output_path` by algorithm of `hash_algorithms` as hex and by algorithm with the suffix `_base64` base64 encoded (e.g. `crc32c_base64`).",
+				ElementType:         types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Update:            true,
+				CreateDescription: "Time the initial build may take (e.g. `30s` or `1h`), including the download of modules. Defaults to `20m`.",
+				UpdateDescription: "Time a rebuild may take (e.g. `30s` or `1h`), including the download of modules. Defaults to `20m`.",
+			}),
+			"ldflags": schema.SingleNestedBlock{
+				MarkdownDescription: "Linker flags passed to `go build -ldflags`.",
+				Attributes: map[string]schema.Attribute{
+					"variables": schema.MapAttribute{
+						MarkdownDescription: "String variables to set via `-X importpath.name=value` (e.g. `main.version = \"v1.0.0\"`).",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"strip_symbols": schema.BoolAttribute{
+						MarkdownDescription: "Omit the symbol table and debug information (`-s`).",
+						Optional:            true,
+					},
+					"strip_dwarf": schema.BoolAttribute{
+						MarkdownDescription: "Omit the DWARF symbol table (`-w`).",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configures the resource.
+func (b *BinaryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	defaults, ok := req.ProviderData.(*ProviderDefaults)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data.",
+			fmt.Sprintf("Expected *ProviderDefaults, but got '%T'.", req.ProviderData),
+		)
+
+		return
+	}
+
+	b.defaults = defaults
+}
+
+// ValidateConfig validates GOOS and GOARCH against the ports of the toolchain and that they are set or defaulted.
+// The validation is skipped until the provider is configured, as the go binary and the defaults of the provider
+// are unknown before, and repeated by `ModifyPlan` once it is.
+func (b *BinaryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if b.defaults == nil {
+		return
+	}
+
+	var goos, goarch types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, fwpath.Root("goos"), &goos)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, fwpath.Root("goarch"), &goarch)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePlatform(ctx, b.defaults.GoBinary, goos, goarch, fwpath.Root("goos"), fwpath.Root("goarch"))...)
+	resp.Diagnostics.Append(validatePlatformResolvable(b.defaults, goos, goarch, fwpath.Root("goos"), fwpath.Root("goarch"))...)
+}
+
+// ModifyPlan computes the source hashes and the output path at plan time
+// and marks the artifact as unknown if a rebuild is required.
+func (b *BinaryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config BinaryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate the platform with the go binary of the provider, which `ValidateConfig` may run without.
+	if resp.Diagnostics.Append(validatePlatform(ctx, providerGoBinary(b.defaults), config.GOOS, config.GOARCH, fwpath.Root("goos"), fwpath.Root("goarch"))...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Diagnostics.Append(validatePlatformResolvable(b.defaults, config.GOOS, config.GOARCH, fwpath.Root("goos"), fwpath.Root("goarch"))...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The hashes can only be computed on apply if any of their inputs is unknown.
+	if !plan.HashInputsKnown(ctx) {
+		plan.ID = types.StringUnknown()
+		plan.OutputPath = types.StringUnknown()
+		plan.setUnknownSourceHashes()
+		plan.setUnknownArtifactHashes()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+
+		return
+	}
+
+	// Take over the provider defaults, so that changing them triggers a rebuild.
+	if b.defaults != nil && config.GOOS.IsNull() && b.defaults.GOOS != "" {
+		plan.GOOS = types.StringValue(b.defaults.GOOS)
+	}
+
+	if b.defaults != nil && config.GOARCH.IsNull() && b.defaults.GOARCH != "" {
+		plan.GOARCH = types.StringValue(b.defaults.GOARCH)
+	}
+
+	conf, diags := plan.Config(ctx, b.defaults)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath, err := filepath.Abs(conf.GetDestination())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			fwpath.Root("destination"),
+			"Invalid destination.",
+			"Unable to get absolute path of destination: '"+err.Error()+"'.",
+		)
+
+		return
+	}
+
+	if archiveFormat := plan.ArchiveFormat.ValueString(); archiveFormat != "" {
+		outputPath += "." + archiveFormat
+	}
+
+	combinedHashes, diags := plan.SourceHashes(ctx, b.defaults, conf)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state BinaryResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Neither the configuration, the provider defaults nor the source changed, so the artifact is kept.
+		if req.Plan.Raw.Equal(req.State.Raw) &&
+			plan.GOOS.Equal(state.GOOS) &&
+			plan.GOARCH.Equal(state.GOARCH) &&
+			state.OutputPath.ValueString() == outputPath &&
+			state.OutputHashes.Equal(hashesValue(combinedHashes)) {
+			return
+		}
+
+		tflog.Trace(ctx, "Source or configuration changed, planning rebuild")
+	}
+
+	plan.SetSourceHashes(combinedHashes)
+	if resp.Diagnostics.Append(plan.SetSourceManifest(ctx, combinedHashes.Files)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(outputPath)
+	plan.OutputPath = types.StringValue(outputPath)
+	plan.setUnknownArtifactHashes()
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create event for this resource.
+func (b *BinaryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BinaryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, defaultBuildTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(data.Build(ctx, b.defaults, data.ArchiveFormat.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.OutputPath
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read event for this resource.
+// The resource is removed from the state if the artifact is missing to trigger a rebuild.
+func (b *BinaryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BinaryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := os.Stat(data.OutputPath.ValueString()); errors.Is(err, os.ErrNotExist) {
+		tflog.Trace(ctx, "Artifact '"+data.OutputPath.ValueString()+"' is missing, removing resource from state")
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update event for this resource.
+func (b *BinaryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state BinaryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, defaultBuildTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(data.Build(ctx, b.defaults, data.ArchiveFormat.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.OutputPath
+
+	// Remove artifacts of the previous build that are not part of this build.
+	paths := data.artifactPaths()
+	for _, path := range state.artifactPaths() {
+		if slices.Contains(paths, path) {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			resp.Diagnostics.AddWarning(
+				"Unable to remove previous artifact.",
+				"Removing '"+path+"' failed with: '"+err.Error()+"'.",
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete event for this resource.
+func (b *BinaryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BinaryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, path := range data.artifactPaths() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			resp.Diagnostics.AddError(
+				"Unable to remove artifact.",
+				"Removing '"+path+"' failed with: '"+err.Error()+"'.",
+			)
+		}
+	}
+}
@@ -0,0 +1,547 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	testresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stevencyb/gopackager/internal/compiler"
+	"github.com/stevencyb/gopackager/internal/hasher"
+	"github.com/stevencyb/gopackager/internal/packager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccResourceFrameworkSatisfaction(t *testing.T) {
+	t.Parallel()
+
+	var _ resource.Resource = &BinaryResource{}
+	var _ resource.ResourceWithModifyPlan = &BinaryResource{}
+	var _ resource.ResourceWithValidateConfig = &BinaryResource{}
+}
+
+// The test replaces the global compiler and can therefore not run in parallel.
+func TestAccBinaryResourcePlatformValidation(t *testing.T) {
+	compilerBackup := globalCompiler
+	t.Cleanup(func() {
+		globalCompiler = compilerBackup
+	})
+
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Ports", mock.Anything, "go1.24").Return(testPorts, nil)
+	globalCompiler = &mockCompiler
+
+	schemaResp := &resource.SchemaResponse{}
+	NewBinaryResource().Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError())
+
+	raw := testConfigValue(t, schemaResp.Schema.Type(), map[string]tftypes.Value{
+		"source":      tftypes.NewValue(tftypes.String, "main.go"),
+		"destination": tftypes.NewValue(tftypes.String, "binary"),
+		"goarch":      tftypes.NewValue(tftypes.String, "amd65"),
+	})
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}
+
+	// The go binary of the provider is unknown before the provider is configured.
+	unconfigured := &BinaryResource{}
+	validateResp := &resource.ValidateConfigResponse{}
+	unconfigured.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, validateResp)
+	assert.False(t, validateResp.Diagnostics.HasError())
+	mockCompiler.AssertNotCalled(t, "Ports", mock.Anything, mock.Anything)
+
+	configured := &BinaryResource{defaults: &ProviderDefaults{GoBinary: "go1.24"}}
+	configured.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, validateResp)
+	assert.True(t, validateResp.Diagnostics.HasError())
+
+	// The plan validates again with the go binary of the provider before hashing the source.
+	planResp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw}}
+	configured.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: config,
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(raw.Type(), nil)},
+	}, planResp)
+	assert.True(t, planResp.Diagnostics.HasError())
+	assert.Contains(t, planResp.Diagnostics.Errors()[0].Detail(), "Did you mean 'amd64'?")
+	mockCompiler.AssertCalled(t, "Ports", mock.Anything, "go1.24")
+}
+
+// The test replaces the global compiler and can therefore not run in parallel.
+func TestAccBinaryResourcePlatformResolution(t *testing.T) {
+	compilerBackup := globalCompiler
+	t.Cleanup(func() {
+		globalCompiler = compilerBackup
+	})
+
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Ports", mock.Anything, mock.Anything).Return(testPorts, nil)
+	globalCompiler = &mockCompiler
+
+	resourceSchema := testBinaryResourceSchema(t)
+	nullState := tftypes.NewValue(resourceSchema.Type().TerraformType(context.Background()), nil)
+	unknownString := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	for name, tc := range map[string]struct {
+		defaults     *ProviderDefaults
+		values       map[string]tftypes.Value
+		expectErrors []string
+	}{
+		"Neither_Set": {
+			defaults:     &ProviderDefaults{},
+			expectErrors: []string{"Missing GOOS.", "Missing GOARCH."},
+		},
+		"GOARCH_Missing": {
+			defaults:     &ProviderDefaults{GOOS: "linux"},
+			expectErrors: []string{"Missing GOARCH."},
+		},
+		"Provider_Defaults": {
+			defaults: &ProviderDefaults{GOOS: "linux", GOARCH: "amd64"},
+		},
+		"Attributes": {
+			defaults: &ProviderDefaults{},
+			values: map[string]tftypes.Value{
+				"goos":   tftypes.NewValue(tftypes.String, "linux"),
+				"goarch": tftypes.NewValue(tftypes.String, "amd64"),
+			},
+		},
+		// Unknown values are resolved on apply.
+		"Unknown": {
+			defaults: &ProviderDefaults{},
+			values:   map[string]tftypes.Value{"goos": unknownString, "goarch": unknownString},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// The unknown source skips hashing, so only the platform is resolved.
+			config := map[string]tftypes.Value{
+				"source":      unknownString,
+				"destination": tftypes.NewValue(tftypes.String, "binary"),
+			}
+			maps.Copy(config, tc.values)
+			raw := testConfigValue(t, resourceSchema.Type(), config)
+
+			validateResp := &resource.ValidateConfigResponse{}
+			binaryResource := &BinaryResource{defaults: tc.defaults}
+			binaryResource.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: resourceSchema, Raw: raw}}, validateResp)
+
+			planResp := testModifyPlan(t, binaryResource, raw, nullState)
+
+			for _, diags := range []diag.Diagnostics{validateResp.Diagnostics, planResp.Diagnostics} {
+				summaries := []string{}
+				for _, d := range diags.Errors() {
+					summaries = append(summaries, d.Summary())
+				}
+				assert.ElementsMatch(t, tc.expectErrors, summaries)
+			}
+		})
+	}
+}
+
+// testBinaryResourceSchema returns the schema of the binary resource.
+func testBinaryResourceSchema(t *testing.T) schema.Schema {
+	t.Helper()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewBinaryResource().Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError())
+
+	return schemaResp.Schema
+}
+
+// testModifyPlan plans the raw config with the prior state and returns the response.
+func testModifyPlan(t *testing.T, binaryResource *BinaryResource, config, state tftypes.Value) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	resourceSchema := testBinaryResourceSchema(t)
+	planResp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: resourceSchema, Raw: config}}
+	binaryResource.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: resourceSchema, Raw: config},
+		Plan:   tfsdk.Plan{Schema: resourceSchema, Raw: config},
+		State:  tfsdk.State{Schema: resourceSchema, Raw: state},
+	}, planResp)
+
+	return planResp
+}
+
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccBinaryResourceModifyPlanUnknownInputs(t *testing.T) {
+	hasherBackup := globalHasher
+	t.Cleanup(func() {
+		globalHasher = hasherBackup
+	})
+
+	mockHasher := hasher.MockHasher{}
+	globalHasher = &mockHasher
+
+	resourceSchema := testBinaryResourceSchema(t)
+	objectType, ok := resourceSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	assert.True(t, ok)
+	ldflagsType, ok := objectType.AttributeTypes["ldflags"].(tftypes.Object)
+	assert.True(t, ok)
+
+	stringMap := tftypes.Map{ElementType: tftypes.String}
+	stringList := tftypes.List{ElementType: tftypes.String}
+	unknownString := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	// Values taken from other resources are unknown until they are applied, also as elements of collections.
+	for name, values := range map[string]map[string]tftypes.Value{
+		"LDFlags_Variables": {"ldflags": tftypes.NewValue(ldflagsType, map[string]tftypes.Value{
+			"variables":     tftypes.NewValue(stringMap, tftypes.UnknownValue),
+			"strip_symbols": tftypes.NewValue(tftypes.Bool, nil),
+			"strip_dwarf":   tftypes.NewValue(tftypes.Bool, nil),
+		})},
+		"LDFlags_Variable": {"ldflags": tftypes.NewValue(ldflagsType, map[string]tftypes.Value{
+			"variables":     tftypes.NewValue(stringMap, map[string]tftypes.Value{"main.commit": unknownString}),
+			"strip_symbols": tftypes.NewValue(tftypes.Bool, nil),
+			"strip_dwarf":   tftypes.NewValue(tftypes.Bool, nil),
+		})},
+		"Env_Element":         {"env": tftypes.NewValue(stringMap, map[string]tftypes.Value{"FOO": unknownString})},
+		"Tags_Element":        {"tags": tftypes.NewValue(stringList, []tftypes.Value{tftypes.NewValue(tftypes.String, "netgo"), unknownString})},
+		"Build_Flags_Element": {"build_flags": tftypes.NewValue(stringList, []tftypes.Value{unknownString})},
+		"Hash_Excludes":       {"hash_excludes": tftypes.NewValue(stringList, tftypes.UnknownValue)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"source":      tftypes.NewValue(tftypes.String, "main.go"),
+				"destination": tftypes.NewValue(tftypes.String, "binary"),
+				"goos":        tftypes.NewValue(tftypes.String, "linux"),
+				"goarch":      tftypes.NewValue(tftypes.String, "amd64"),
+			}
+			maps.Copy(config, values)
+
+			planResp := testModifyPlan(t, &BinaryResource{}, testConfigValue(t, resourceSchema.Type(), config), tftypes.NewValue(objectType, nil))
+			assert.False(t, planResp.Diagnostics.HasError(), planResp.Diagnostics)
+
+			var plan BinaryResourceModel
+			assert.False(t, planResp.Plan.Get(context.Background(), &plan).HasError())
+			assert.True(t, plan.OutputSHA256.IsUnknown())
+			assert.True(t, plan.OutputHashes.IsUnknown())
+			assert.True(t, plan.OutputPath.IsUnknown())
+			assert.True(t, plan.ArtifactHashes.IsUnknown())
+		})
+	}
+
+	mockHasher.AssertNotCalled(t, "HashDir", mock.Anything, mock.Anything)
+}
+
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccBinaryResource(t *testing.T) {
+	compilerBackup, packagersBackup, hasherBackup := globalCompiler, globalPackagers, globalHasher
+	t.Cleanup(func() {
+		globalCompiler, globalPackagers, globalHasher = compilerBackup, packagersBackup, hasherBackup
+	})
+
+	sourceDir := t.TempDir()
+	outputDir := t.TempDir()
+	source := filepath.Join(sourceDir, "main.go")
+	destination := filepath.Join(outputDir, "binary")
+	assert.NoError(t, os.WriteFile(source, []byte("package main\n\nfunc main() {}\n"), 0o600))
+
+	// The mock compiler writes the source as binary, so that the artifact changes with the source.
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Compile", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		conf := args.Get(1).(compiler.Config) //nolint:forcetypeassert
+		content, err := os.ReadFile(conf.GetSource())
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(conf.GetDestination(), content, 0o600))
+	}).Return(destination, nil).Times(4)
+	mockCompiler.On("Ports", mock.Anything, "").Return(testPorts, nil)
+	globalCompiler = &mockCompiler
+	globalPackagers = packager.Packagers()
+	globalHasher = hasher.New()
+
+	testAccProtoV6ProviderFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"gopackager": providerserver.NewProtocol6WithError(New("test")()),
+	}
+
+	var initialSourceHash, initialArtifactHash string
+
+	testresource.Test(t, testresource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			for _, path := range []string{destination, destination + ".zip"} {
+				if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("expected artifact '%s' to be deleted", path)
+				}
+			}
+
+			return nil
+		},
+		Steps: []testresource.TestStep{
+			// Invalid GOARCH testing
+			{
+				Config:      strings.Replace(binaryResourceConfig(source, destination, ""), `goarch = "amd64"`, `goarch = "amd46"`, 1),
+				ExpectError: regexp.MustCompile(`Did you\s+mean 'amd64'`),
+			},
+			// Invalid provider GOOS testing
+			{
+				Config: `
+provider "gopackager" {
+	goos = "windoes"
+}
+` + binaryResourceConfig(source, destination, ""),
+				ExpectError: regexp.MustCompile(`Did you\s+mean 'windows'`),
+			},
+			// Create testing
+			{
+				Config: binaryResourceConfig(source, destination, ""),
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttr("gopackager_binary.test", "id", destination),
+					testresource.TestCheckResourceAttr("gopackager_binary.test", "output_path", destination),
+					testresource.TestCheckResourceAttrWith("gopackager_binary.test", "output_sha256", func(value string) error {
+						initialSourceHash = value
+
+						return nil
+					}),
+					testresource.TestCheckResourceAttrWith("gopackager_binary.test", "artifact_sha256", func(value string) error {
+						initialArtifactHash = value
+
+						return nil
+					}),
+					testresource.TestCheckResourceAttrSet("gopackager_binary.test", "artifact_sha256_base64"),
+				),
+			},
+			// Unchanged source doesn't plan a rebuild
+			{
+				Config:   binaryResourceConfig(source, destination, ""),
+				PlanOnly: true,
+			},
+			// Changed source triggers a rebuild
+			{
+				PreConfig: func() {
+					assert.NoError(t, os.WriteFile(source, []byte("package main\n\nfunc main() { println(1) }\n"), 0o600))
+				},
+				Config: binaryResourceConfig(source, destination, ""),
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttrWith("gopackager_binary.test", "output_sha256", func(value string) error {
+						if value == initialSourceHash {
+							return errors.New("expected source hash to change")
+						}
+
+						return nil
+					}),
+					testresource.TestCheckResourceAttrWith("gopackager_binary.test", "artifact_sha256", func(value string) error {
+						if value == initialArtifactHash {
+							return errors.New("expected artifact hash to change")
+						}
+
+						return nil
+					}),
+				),
+			},
+			// Changed configuration triggers a rebuild
+			{
+				Config: binaryResourceConfig(source, destination, "zip"),
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttr("gopackager_binary.test", "id", destination+".zip"),
+					testresource.TestCheckResourceAttr("gopackager_binary.test", "output_path", destination+".zip"),
+					testresource.TestCheckResourceAttrWith("gopackager_binary.test", "output_path", func(value string) error {
+						_, err := os.Stat(value)
+
+						return err
+					}),
+				),
+			},
+			// Changed provider defaults trigger a rebuild
+			{
+				Config: fmt.Sprintf(`
+provider "gopackager" {
+	goos = "linux"
+	goarch = "arm64"
+	artifact_dir = %q
+}
+
+resource "gopackager_binary" "test" {
+	source = %q
+	destination = "binary"
+	archive_format = "zip"
+}
+				`, outputDir, source),
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttr("gopackager_binary.test", "goos", "linux"),
+					testresource.TestCheckResourceAttr("gopackager_binary.test", "goarch", "arm64"),
+					testresource.TestCheckResourceAttr("gopackager_binary.test", "output_path", destination+".zip"),
+				),
+			},
+		},
+	})
+
+	mockCompiler.AssertExpectations(t)
+}
+
+// The lifecycle is tested by calling the resource directly, so it doesn't require Terraform like `TestAccBinaryResource`.
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccBinaryResourceLifecycle(t *testing.T) {
+	compilerBackup, packagersBackup, hasherBackup := globalCompiler, globalPackagers, globalHasher
+	t.Cleanup(func() {
+		globalCompiler, globalPackagers, globalHasher = compilerBackup, packagersBackup, hasherBackup
+	})
+
+	sourceDir := t.TempDir()
+	outputDir := t.TempDir()
+	source := filepath.Join(sourceDir, "main.go")
+	destination := filepath.Join(outputDir, "binary")
+	manifest := filepath.Join(outputDir, "manifest.json")
+	assert.NoError(t, os.WriteFile(source, []byte("package main\n\nfunc main() {}\n"), 0o600))
+
+	// The mock compiler writes the source as binary, so that the artifact changes with the source.
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Compile", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		conf := args.Get(1).(compiler.Config) //nolint:forcetypeassert
+		content, err := os.ReadFile(conf.GetSource())
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(conf.GetDestination(), content, 0o600))
+	}).Return(destination, nil).Times(3)
+	mockCompiler.On("Ports", mock.Anything, mock.Anything).Return(testPorts, nil)
+	globalCompiler = &mockCompiler
+	globalPackagers = packager.Packagers()
+	globalHasher = hasher.New()
+
+	ctx := context.Background()
+	resourceSchema := testBinaryResourceSchema(t)
+	nullState := tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)
+	binaryResource := &BinaryResource{defaults: &ProviderDefaults{}}
+
+	config := func(archiveFormat string) tfsdk.Config {
+		values := map[string]tftypes.Value{
+			"source":               tftypes.NewValue(tftypes.String, source),
+			"destination":          tftypes.NewValue(tftypes.String, destination),
+			"goos":                 tftypes.NewValue(tftypes.String, "linux"),
+			"goarch":               tftypes.NewValue(tftypes.String, "amd64"),
+			"source_manifest_file": tftypes.NewValue(tftypes.String, manifest),
+		}
+		if archiveFormat != "" {
+			values["archive_format"] = tftypes.NewValue(tftypes.String, archiveFormat)
+		}
+
+		return tfsdk.Config{Schema: resourceSchema, Raw: testConfigValue(t, resourceSchema.Type(), values)}
+	}
+
+	// modifyPlan plans the proposed new state, which Terraform derives from the config and the prior state.
+	modifyPlan := func(t *testing.T, config tfsdk.Config, proposed, prior tftypes.Value) tfsdk.Plan {
+		t.Helper()
+
+		resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: resourceSchema, Raw: proposed}}
+		binaryResource.ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Config: config,
+			Plan:   tfsdk.Plan{Schema: resourceSchema, Raw: proposed},
+			State:  tfsdk.State{Schema: resourceSchema, Raw: prior},
+		}, resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		return resp.Plan
+	}
+
+	model := func(t *testing.T, data interface {
+		Get(context.Context, any) diag.Diagnostics
+	}) BinaryResourceModel {
+		t.Helper()
+
+		var model BinaryResourceModel
+		assert.False(t, data.Get(ctx, &model).HasError())
+
+		return model
+	}
+
+	// Create computes the source hashes at plan time and the artifact hashes on apply.
+	createPlan := modifyPlan(t, config(""), config("").Raw, nullState)
+	planned := model(t, createPlan)
+	assert.False(t, planned.OutputSHA256.IsUnknown())
+	assert.Equal(t, destination, planned.OutputPath.ValueString())
+	assert.True(t, planned.ArtifactSHA256.IsUnknown())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: resourceSchema, Raw: nullState}}
+	binaryResource.Create(ctx, resource.CreateRequest{Config: config(""), Plan: createPlan}, createResp)
+	assert.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	assert.True(t, createResp.State.Raw.IsFullyKnown())
+	created := model(t, createResp.State)
+	assert.Equal(t, planned.OutputHashes, created.OutputHashes, "the applied hashes must match the plan")
+	assert.Equal(t, destination, created.ID.ValueString())
+	assert.False(t, created.ArtifactSHA256.IsUnknown())
+	assert.FileExists(t, destination)
+	assert.FileExists(t, manifest)
+
+	// Nothing changed, so the plan keeps the prior state and the artifact isn't rebuilt.
+	unchangedPlan := modifyPlan(t, config(""), createResp.State.Raw, createResp.State.Raw)
+	assert.True(t, unchangedPlan.Raw.Equal(createResp.State.Raw))
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	binaryResource.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.Equal(createResp.State.Raw))
+
+	// A changed source plans a rebuild with the new source hashes.
+	assert.NoError(t, os.WriteFile(source, []byte("package main\n\nfunc main() { println(1) }\n"), 0o600))
+	changedPlan := modifyPlan(t, config(""), createResp.State.Raw, createResp.State.Raw)
+	changed := model(t, changedPlan)
+	assert.NotEqual(t, created.OutputSHA256, changed.OutputSHA256)
+	assert.True(t, changed.ArtifactSHA256.IsUnknown())
+
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	binaryResource.Update(ctx, resource.UpdateRequest{Config: config(""), Plan: changedPlan, State: createResp.State}, updateResp)
+	assert.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	updated := model(t, updateResp.State)
+	assert.Equal(t, changed.OutputHashes, updated.OutputHashes)
+	assert.NotEqual(t, created.ArtifactSHA256, updated.ArtifactSHA256)
+
+	// A changed configuration plans a rebuild with the new output path.
+	proposed := updated
+	proposed.ArchiveFormat = types.StringValue("zip")
+	proposedPlan := tfsdk.Plan{Schema: resourceSchema}
+	assert.False(t, proposedPlan.Set(ctx, &proposed).HasError())
+
+	archivePlan := modifyPlan(t, config("zip"), proposedPlan.Raw, updateResp.State.Raw)
+	assert.Equal(t, destination+".zip", model(t, archivePlan).OutputPath.ValueString())
+	assert.True(t, model(t, archivePlan).ArtifactSHA256.IsUnknown())
+
+	archiveResp := &resource.UpdateResponse{State: updateResp.State}
+	binaryResource.Update(ctx, resource.UpdateRequest{Config: config("zip"), Plan: archivePlan, State: updateResp.State}, archiveResp)
+	assert.False(t, archiveResp.Diagnostics.HasError(), archiveResp.Diagnostics)
+	assert.FileExists(t, destination+".zip")
+
+	// Destroy removes the artifacts and the manifest, after which the resource is gone on refresh.
+	deleteResp := &resource.DeleteResponse{State: archiveResp.State}
+	binaryResource.Delete(ctx, resource.DeleteRequest{State: archiveResp.State}, deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	for _, path := range []string{destination, destination + ".zip", manifest} {
+		assert.NoFileExists(t, path)
+	}
+
+	readResp = &resource.ReadResponse{State: archiveResp.State}
+	binaryResource.Read(ctx, resource.ReadRequest{State: archiveResp.State}, readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+
+	mockCompiler.AssertExpectations(t)
+}
+
+func binaryResourceConfig(source, destination, archiveFormat string) string {
+	archive := ""
+	if archiveFormat != "" {
+		archive = fmt.Sprintf("archive_format = %q", archiveFormat)
+	}
+
+	return fmt.Sprintf(`
+resource "gopackager_binary" "test" {
+	source = %q
+	destination = %q
+	goos = "linux"
+	goarch = "amd64"
+	%s
+}
+	`, source, destination, archive)
+}
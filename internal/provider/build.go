@@ -0,0 +1,693 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/stevencyb/gopackager/internal/compiler"
+	"github.com/stevencyb/gopackager/internal/hasher"
+	"github.com/stevencyb/gopackager/internal/packager"
+)
+
+// This is the global compiler instance.
+// This instance is replaced by the mock instance during tests.
+var globalCompiler compiler.CompilerI = compiler.New()
+
+// These are the global packager instances by archive format.
+// These instances are replaced by mock instances during tests.
+var globalPackagers = packager.Packagers()
+
+// This is the global hasher instance.
+// This instance is replaced by the mock instance during tests.
+var globalHasher hasher.HasherI = hasher.New()
+
+// Modes to select the source files for the `output_*` hashes.
+const (
+	// hashModeDir hashes all files in the base path.
+	hashModeDir = "dir"
+	// hashModeDeps hashes only the files the binary is built from.
+	hashModeDeps = "deps"
+)
+
+// defaultHashIgnoreFiles are the ignore files honoured if `hash_ignore_files` is not set.
+var defaultHashIgnoreFiles = []string{".gitignore", hasher.DockerIgnoreFile}
+
+// defaultBuildTimeout is the time a build may take if no timeout is configured.
+const defaultBuildTimeout = 20 * time.Minute
+
+// fileModePattern matches octal file permissions like `0755` or `644`.
+var fileModePattern = regexp.MustCompile(`^0?[0-7]{3}$`)
+
+// BuildModel is the model for the build settings and outputs,
+// shared by the compile data source and the binary resource.
+type BuildModel struct {
+	// Input
+	Source      types.String `tfsdk:"source"`
+	Destination types.String `tfsdk:"destination"`
+	GOOS        types.String `tfsdk:"goos"`
+	GOARCH      types.String `tfsdk:"goarch"`
+	// Optional
+	ArchiveFormat  types.String  `tfsdk:"archive_format"`
+	ZIPResources   types.Map     `tfsdk:"zip_resources"`
+	ZIPFileModes   types.Map     `tfsdk:"zip_file_modes"`
+	ZIPExcludes    types.List    `tfsdk:"zip_excludes"`
+	BasePath       types.String  `tfsdk:"base_path"`
+	HashMode       types.String  `tfsdk:"hash_mode"`
+	HashExcludes   types.List    `tfsdk:"hash_excludes"`
+	HashIgnore     types.List    `tfsdk:"hash_ignore_files"`
+	HashAlgorithms types.List    `tfsdk:"hash_algorithms"`
+	Tags           types.List    `tfsdk:"tags"`
+	CGOEnabled     types.Bool    `tfsdk:"cgo_enabled"`
+	Env            types.Map     `tfsdk:"env"`
+	Reproducible   types.Bool    `tfsdk:"reproducible"`
+	BuildFlags     types.List    `tfsdk:"build_flags"`
+	LDFlags        *LDFlagsModel `tfsdk:"ldflags"`
+	// Source manifest
+	SourceManifestEnabled types.Bool   `tfsdk:"source_manifest_enabled"`
+	SourceManifestFile    types.String `tfsdk:"source_manifest_file"`
+	SourceManifest        types.Map    `tfsdk:"source_manifest"`
+	// Output
+	OutputPath         types.String `tfsdk:"output_path"`
+	OutputMD5          types.String `tfsdk:"output_md5"`
+	OutputSHA1         types.String `tfsdk:"output_sha1"`
+	OutputSHA256       types.String `tfsdk:"output_sha256"`
+	OutputSHA512       types.String `tfsdk:"output_sha512"`
+	OutputSHA256Base64 types.String `tfsdk:"output_sha256_base64"`
+	OutputSHA512Base64 types.String `tfsdk:"output_sha512_base64"`
+	OutputHashes       types.Map    `tfsdk:"output_hashes"`
+	// Artifact output
+	ArtifactMD5          types.String `tfsdk:"artifact_md5"`
+	ArtifactSHA1         types.String `tfsdk:"artifact_sha1"`
+	ArtifactSHA256       types.String `tfsdk:"artifact_sha256"`
+	ArtifactSHA512       types.String `tfsdk:"artifact_sha512"`
+	ArtifactSHA256Base64 types.String `tfsdk:"artifact_sha256_base64"`
+	ArtifactSHA512Base64 types.String `tfsdk:"artifact_sha512_base64"`
+	ArtifactHashes       types.Map    `tfsdk:"artifact_hashes"`
+}
+
+// LDFlagsModel is the model for the linker flags block.
+type LDFlagsModel struct {
+	Variables    types.Map  `tfsdk:"variables"`
+	StripSymbols types.Bool `tfsdk:"strip_symbols"`
+	StripDWARF   types.Bool `tfsdk:"strip_dwarf"`
+}
+
+// LDFlags converts the model into the compiler linker flags.
+func (l *LDFlagsModel) LDFlags(ctx context.Context) (compiler.LDFlags, diag.Diagnostics) {
+	ldflags := compiler.LDFlags{
+		StripSymbols: l.StripSymbols.ValueBool(),
+		StripDWARF:   l.StripDWARF.ValueBool(),
+	}
+
+	if l.Variables.IsNull() || l.Variables.IsUnknown() {
+		return ldflags, nil
+	}
+
+	ldflags.Variables = map[string]string{}
+	diags := l.Variables.ElementsAs(ctx, &ldflags.Variables, false)
+
+	return ldflags, diags
+}
+
+// Config creates the compiler configuration from the model.
+// Values of the model override the provider defaults.
+func (b *BuildModel) Config(ctx context.Context, defaults *ProviderDefaults) (*compiler.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if defaults == nil {
+		defaults = &ProviderDefaults{}
+	}
+
+	destination := b.Destination.ValueString()
+	if defaults.ArtifactDir != "" && destination != "" && !filepath.IsAbs(destination) {
+		destination = filepath.Join(defaults.ArtifactDir, destination)
+	}
+
+	conf := compiler.NewConfig().
+		Source(b.Source.ValueString()).
+		Destination(destination).
+		GOOS(valueOrDefault(b.GOOS, defaults.GOOS)).
+		GOARCH(valueOrDefault(b.GOARCH, defaults.GOARCH)).
+		GoBinary(defaults.GoBinary).
+		CacheDir(defaults.BuildCacheDir).
+		BuildFlags(defaults.BuildFlags)
+	if b.LDFlags != nil {
+		ldflags, ldflagsDiags := b.LDFlags.LDFlags(ctx)
+		if diags.Append(ldflagsDiags...); diags.HasError() {
+			return nil, diags
+		}
+
+		conf = conf.LDFlags(ldflags)
+	}
+
+	if !b.Tags.IsNull() && !b.Tags.IsUnknown() {
+		tags := []string{}
+		if diags.Append(b.Tags.ElementsAs(ctx, &tags, false)...); diags.HasError() {
+			return nil, diags
+		}
+
+		conf = conf.Tags(tags)
+	}
+
+	if !b.CGOEnabled.IsNull() && !b.CGOEnabled.IsUnknown() {
+		conf = conf.CGOEnabled(b.CGOEnabled.ValueBool())
+	}
+
+	env := maps.Clone(defaults.Env)
+	if !b.Env.IsNull() && !b.Env.IsUnknown() {
+		blockEnv := map[string]string{}
+		if diags.Append(b.Env.ElementsAs(ctx, &blockEnv, false)...); diags.HasError() {
+			return nil, diags
+		}
+
+		if env == nil {
+			env = map[string]string{}
+		}
+
+		maps.Copy(env, blockEnv)
+	}
+
+	if env != nil {
+		conf = conf.Env(env)
+	}
+
+	if !b.BuildFlags.IsNull() && !b.BuildFlags.IsUnknown() {
+		buildFlags := []string{}
+		if diags.Append(b.BuildFlags.ElementsAs(ctx, &buildFlags, false)...); diags.HasError() {
+			return nil, diags
+		}
+
+		conf = conf.BuildFlags(buildFlags)
+	}
+
+	if !b.Reproducible.IsNull() && !b.Reproducible.IsUnknown() {
+		conf = conf.Reproducible(b.Reproducible.ValueBool())
+	}
+
+	return conf, diags
+}
+
+// valueOrDefault returns the value if it is set or the default otherwise.
+func valueOrDefault(value types.String, defaultValue string) string {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultValue
+	}
+
+	return value.ValueString()
+}
+
+// HashInputsKnown reports whether all values that affect the source hashes and the output path are fully known,
+// including the elements of lists and maps (e.g. `env = { FOO = x.id }`).
+func (b *BuildModel) HashInputsKnown(ctx context.Context) bool {
+	inputs := []attr.Value{
+		b.Source,
+		b.Destination,
+		b.ArchiveFormat,
+		b.BasePath,
+		b.Tags,
+		b.CGOEnabled,
+		b.Env,
+		b.Reproducible,
+		b.BuildFlags,
+		b.HashMode,
+		b.HashExcludes,
+		b.HashIgnore,
+		b.HashAlgorithms,
+		b.SourceManifestEnabled,
+		b.SourceManifestFile,
+	}
+	if b.LDFlags != nil {
+		inputs = append(inputs, b.LDFlags.Variables, b.LDFlags.StripSymbols, b.LDFlags.StripDWARF)
+	}
+
+	for _, input := range inputs {
+		if !fullyKnown(ctx, input) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fullyKnown reports whether the value and all of its elements are known.
+func fullyKnown(ctx context.Context, value attr.Value) bool {
+	if value.IsUnknown() {
+		return false
+	}
+
+	tfValue, err := value.ToTerraformValue(ctx)
+
+	return err == nil && tfValue.IsFullyKnown()
+}
+
+// hashAlgorithms returns the selected hash algorithms, which are nil for the default algorithms.
+func (b *BuildModel) hashAlgorithms(ctx context.Context) ([]string, diag.Diagnostics) {
+	if b.HashAlgorithms.IsNull() || b.HashAlgorithms.IsUnknown() {
+		return nil, nil
+	}
+
+	algorithms := []string{}
+	diags := b.HashAlgorithms.ElementsAs(ctx, &algorithms, false)
+
+	return algorithms, diags
+}
+
+// archive packages the compiled binary and the additional resources and returns the archive path.
+func (b *BuildModel) archive(ctx context.Context, binaryPath, archiveFormat string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	additionalFiles := map[string]string{}
+	if !b.ZIPResources.IsNull() && !b.ZIPResources.IsUnknown() {
+		if diags.Append(b.ZIPResources.ElementsAs(ctx, &additionalFiles, false)...); diags.HasError() {
+			return "", diags
+		}
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Archiving compiled binary as %s with %d additional files", archiveFormat, len(additionalFiles)))
+
+	var fileModes map[string]os.FileMode
+	if !b.ZIPFileModes.IsNull() && !b.ZIPFileModes.IsUnknown() {
+		modes := map[string]string{}
+		if diags.Append(b.ZIPFileModes.ElementsAs(ctx, &modes, false)...); diags.HasError() {
+			return "", diags
+		}
+
+		fileModes = map[string]os.FileMode{}
+		for name, mode := range modes {
+			perm, err := strconv.ParseUint(mode, 8, 32)
+			if err != nil {
+				diags.AddAttributeError(
+					fwpath.Root("zip_file_modes").AtMapKey(name),
+					"Invalid file mode.",
+					"Expected an octal file permission like '0755', but got '"+mode+"'.",
+				)
+
+				return "", diags
+			}
+
+			fileModes[name] = os.FileMode(perm)
+		}
+	}
+
+	var excludes []string
+	if !b.ZIPExcludes.IsNull() && !b.ZIPExcludes.IsUnknown() {
+		if diags.Append(b.ZIPExcludes.ElementsAs(ctx, &excludes, false)...); diags.HasError() {
+			return "", diags
+		}
+	}
+
+	additionalFiles[binaryPath] = filepath.Base(binaryPath)
+	archivePath := binaryPath + "." + archiveFormat
+
+	if err := globalPackagers[archiveFormat].Package(archivePath, additionalFiles, fileModes, excludes); err != nil {
+		diags.AddError(
+			"Unable to create archive.",
+			"Archiving as "+archiveFormat+" failed with: '"+err.Error()+"'.",
+		)
+
+		return "", diags
+	}
+
+	return archivePath, diags
+}
+
+// SourceHashes computes the hashes of the source files for the given configuration.
+func (b *BuildModel) SourceHashes(ctx context.Context, defaults *ProviderDefaults, conf *compiler.Config) (*hasher.CombinedHash, diag.Diagnostics) {
+	dirHashes, diags := b.hashSources(ctx, defaults, conf)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	saltedHashes, saltDiags := b.saltHashes(ctx, dirHashes, conf)
+	diags.Append(saltDiags...)
+
+	return saltedHashes, diags
+}
+
+// dirOptions returns the options to hash the base path.
+// The binaries and archives of the configurations and the source manifest are always excluded,
+// otherwise building into the base path would change the hashes with every build.
+func (b *BuildModel) dirOptions(ctx context.Context, defaults *ProviderDefaults, confs ...*compiler.Config) (hasher.DirOptions, diag.Diagnostics) {
+	algorithms, diags := b.hashAlgorithms(ctx)
+	if diags.HasError() {
+		return hasher.DirOptions{}, diags
+	}
+
+	opts := hasher.DirOptions{IgnoreFiles: defaultHashIgnoreFiles, Algorithms: algorithms}
+	if defaults != nil {
+		opts.Concurrency = defaults.HashConcurrency
+		opts.CacheDir = defaults.HashCacheDir
+	}
+	if !b.HashIgnore.IsNull() && !b.HashIgnore.IsUnknown() {
+		opts.IgnoreFiles = []string{}
+		if diags.Append(b.HashIgnore.ElementsAs(ctx, &opts.IgnoreFiles, false)...); diags.HasError() {
+			return opts, diags
+		}
+	}
+
+	if !b.HashExcludes.IsNull() && !b.HashExcludes.IsUnknown() {
+		if diags.Append(b.HashExcludes.ElementsAs(ctx, &opts.Excludes, false)...); diags.HasError() {
+			return opts, diags
+		}
+	}
+
+	if manifestPath := b.SourceManifestFile.ValueString(); manifestPath != "" {
+		opts.ExcludePaths = append(opts.ExcludePaths, manifestPath)
+	}
+
+	for _, conf := range confs {
+		destination := conf.GetDestination()
+		opts.ExcludePaths = append(opts.ExcludePaths, destination)
+		for _, format := range packager.Formats() {
+			opts.ExcludePaths = append(opts.ExcludePaths, destination+"."+format)
+		}
+	}
+
+	return opts, diags
+}
+
+// hashSources computes the hashes of the source files in the base path.
+// By default all files in the base path are hashed. With `hash_mode = "deps"` only
+// the files the binary is built from for any of the configurations are hashed.
+func (b *BuildModel) hashSources(ctx context.Context, defaults *ProviderDefaults, confs ...*compiler.Config) (*hasher.CombinedHash, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	baseTriggerPath := filepath.Dir(b.Source.ValueString())
+	if !b.BasePath.IsNull() && !b.BasePath.IsUnknown() {
+		baseTriggerPath = b.BasePath.ValueString()
+	}
+
+	if b.HashMode.ValueString() != hashModeDeps {
+		opts, optsDiags := b.dirOptions(ctx, defaults, confs...)
+		if diags.Append(optsDiags...); diags.HasError() {
+			return nil, diags
+		}
+
+		combinedHashes, err := globalHasher.HashDir(baseTriggerPath, opts)
+		if err != nil {
+			diags.AddError(
+				"Unable to compute hashes.",
+				"Hashing failed with: '"+err.Error()+"'.",
+			)
+
+			return nil, diags
+		}
+
+		return combinedHashes, diags
+	}
+
+	files := []string{}
+	for _, conf := range confs {
+		confFiles, err := globalCompiler.SourceFiles(ctx, *conf)
+		if err != nil {
+			diags.AddAttributeError(
+				fwpath.Root("hash_mode"),
+				"Unable to list source files.",
+				"Listing the dependencies of '"+conf.GetSource()+"' failed with: '"+err.Error()+"'.",
+			)
+
+			return nil, diags
+		}
+
+		files = append(files, confFiles...)
+	}
+
+	slices.Sort(files)
+
+	// The source files are absolute, so the base path has to be as well to hash them by their relative path.
+	root, err := filepath.Abs(baseTriggerPath)
+	if err != nil {
+		diags.AddError(
+			"Unable to compute hashes.",
+			"Resolving the base path '"+baseTriggerPath+"' failed with: '"+err.Error()+"'.",
+		)
+
+		return nil, diags
+	}
+
+	algorithms, algorithmsDiags := b.hashAlgorithms(ctx)
+	if diags.Append(algorithmsDiags...); diags.HasError() {
+		return nil, diags
+	}
+
+	opts := hasher.FilesOptions{Algorithms: algorithms}
+	if defaults != nil {
+		opts.Concurrency = defaults.HashConcurrency
+		opts.CacheDir = defaults.HashCacheDir
+	}
+
+	combinedHashes, err := globalHasher.HashFiles(root, slices.Compact(files), opts)
+	if err != nil {
+		diags.AddError(
+			"Unable to compute hashes.",
+			"Hashing failed with: '"+err.Error()+"'.",
+		)
+
+		return nil, diags
+	}
+
+	return combinedHashes, diags
+}
+
+// saltHashes folds the build settings into the hashes of the source files.
+// Build settings like tags or environment are not part of the source files,
+// so they are folded into the hash to trigger a new output on change.
+func (b *BuildModel) saltHashes(ctx context.Context, dirHashes *hasher.CombinedHash, conf *compiler.Config) (*hasher.CombinedHash, diag.Diagnostics) {
+	fingerprint := conf.Fingerprint()
+	if fingerprint == "" {
+		return dirHashes, nil
+	}
+
+	algorithms, diags := b.hashAlgorithms(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	saltedHashes, err := globalHasher.CombinedHash([]byte(dirHashes.Digests()+"\n"+fingerprint), algorithms)
+	if err != nil {
+		diags.AddError(
+			"Unable to compute hashes.",
+			"Hashing the build settings failed with: '"+err.Error()+"'.",
+		)
+
+		return nil, diags
+	}
+
+	// The salt only changes the hashes, the hashed files stay the same.
+	saltedHashes.Files = dirHashes.Files
+
+	return saltedHashes, diags
+}
+
+// SetSourceHashes sets the `output_*` hashes.
+func (b *BuildModel) SetSourceHashes(combinedHashes *hasher.CombinedHash) {
+	b.OutputMD5 = hashValue(combinedHashes.MD5)
+	b.OutputSHA1 = hashValue(combinedHashes.SHA1)
+	b.OutputSHA256 = hashValue(combinedHashes.SHA256)
+	b.OutputSHA512 = hashValue(combinedHashes.SHA512)
+	b.OutputSHA256Base64 = hashValue(combinedHashes.SHA256Base64)
+	b.OutputSHA512Base64 = hashValue(combinedHashes.SHA512Base64)
+	b.OutputHashes = hashesValue(combinedHashes)
+}
+
+// setUnknownSourceHashes marks the `output_*` hashes and the source manifest as unknown until they are computed.
+func (b *BuildModel) setUnknownSourceHashes() {
+	b.OutputMD5 = types.StringUnknown()
+	b.OutputSHA1 = types.StringUnknown()
+	b.OutputSHA256 = types.StringUnknown()
+	b.OutputSHA512 = types.StringUnknown()
+	b.OutputSHA256Base64 = types.StringUnknown()
+	b.OutputSHA512Base64 = types.StringUnknown()
+	b.OutputHashes = types.MapUnknown(types.StringType)
+	b.SourceManifest = types.MapUnknown(manifestEntryType)
+}
+
+// setUnknownArtifactHashes marks the `artifact_*` hashes as unknown until the artifact is built.
+func (b *BuildModel) setUnknownArtifactHashes() {
+	b.ArtifactMD5 = types.StringUnknown()
+	b.ArtifactSHA1 = types.StringUnknown()
+	b.ArtifactSHA256 = types.StringUnknown()
+	b.ArtifactSHA512 = types.StringUnknown()
+	b.ArtifactSHA256Base64 = types.StringUnknown()
+	b.ArtifactSHA512Base64 = types.StringUnknown()
+	b.ArtifactHashes = types.MapUnknown(types.StringType)
+}
+
+// setArtifactHashes sets the `artifact_*` hashes.
+func (b *BuildModel) setArtifactHashes(combinedHashes *hasher.CombinedHash) {
+	b.ArtifactMD5 = hashValue(combinedHashes.MD5)
+	b.ArtifactSHA1 = hashValue(combinedHashes.SHA1)
+	b.ArtifactSHA256 = hashValue(combinedHashes.SHA256)
+	b.ArtifactSHA512 = hashValue(combinedHashes.SHA512)
+	b.ArtifactSHA256Base64 = hashValue(combinedHashes.SHA256Base64)
+	b.ArtifactSHA512Base64 = hashValue(combinedHashes.SHA512Base64)
+	b.ArtifactHashes = hashesValue(combinedHashes)
+}
+
+// hashValue returns the digest, which is null if its algorithm isn't selected.
+func hashValue(digest string) types.String {
+	if digest == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(digest)
+}
+
+// hashesValue returns the digests of all selected algorithms as map.
+func hashesValue(combinedHashes *hasher.CombinedHash) types.Map {
+	elements := make(map[string]attr.Value, len(combinedHashes.Hashes))
+	for name, digest := range combinedHashes.Hashes {
+		elements[name] = types.StringValue(digest)
+	}
+
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// Build compiles the binary, archives it if an archive format is given and sets all outputs.
+func (b *BuildModel) Build(ctx context.Context, defaults *ProviderDefaults, archiveFormat string) diag.Diagnostics {
+	tflog.Trace(ctx, "Checking configuration")
+
+	conf, diags := b.Config(ctx, defaults)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := conf.Verify(); err != nil {
+		diags.AddError(
+			"Invalid configuration.",
+			"Expected configuration to be valid, but got '"+err.Error()+"'.",
+		)
+
+		return diags
+	}
+
+	// The source is hashed before compiling, so that a binary written
+	// into the watched directory doesn't change the hashes of this build.
+	tflog.Trace(ctx, "Compute hashes")
+	dirHashes, hashDiags := b.hashSources(ctx, defaults, conf)
+	if diags.Append(hashDiags...); diags.HasError() {
+		return diags
+	}
+
+	if diags.Append(b.compile(ctx, conf, archiveFormat, dirHashes)...); diags.HasError() {
+		return diags
+	}
+
+	diags.Append(b.writeSourceManifest(dirHashes.Files)...)
+
+	return diags
+}
+
+// compile compiles the binary for the verified configuration, archives it
+// if an archive format is given and sets all outputs.
+func (b *BuildModel) compile(ctx context.Context, conf *compiler.Config, archiveFormat string, dirHashes *hasher.CombinedHash) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tflog.Trace(ctx, "Compiling GoLang source code")
+
+	outputPath, err := globalCompiler.Compile(ctx, *conf)
+	buildErr := &compiler.BuildError{}
+	switch {
+	case errors.Is(err, compiler.ErrBuildTimedOut):
+		diags.AddError(
+			"Build timed out.",
+			"Compiling '"+conf.GetSource()+"' didn't finish in time and was aborted, the timeout can be increased via `timeouts`.",
+		)
+
+		return diags
+	case errors.As(err, &buildErr) && len(buildErr.Errors) > 0:
+		// The complete output is only logged, as it mostly contains noise like module downloads.
+		tflog.Debug(ctx, "Compiling go code failed", map[string]any{"command": buildErr.Command, "output": buildErr.Output})
+		diags.Append(compileErrorDiagnostics(conf.GetSource(), buildErr.Errors)...)
+
+		return diags
+	case err != nil:
+		diags.AddError(
+			"Unable to compile binary.",
+			"Compiling go code failed due '"+err.Error()+"'.",
+		)
+
+		return diags
+	}
+
+	if archiveFormat != "" {
+		archivePath, archiveDiags := b.archive(ctx, outputPath, archiveFormat)
+		if diags.Append(archiveDiags...); diags.HasError() {
+			return diags
+		}
+
+		outputPath = archivePath
+	}
+
+	tflog.Trace(ctx, "Compute artifact hashes")
+	algorithms, algorithmsDiags := b.hashAlgorithms(ctx)
+	if diags.Append(algorithmsDiags...); diags.HasError() {
+		return diags
+	}
+
+	artifactHashes, err := globalHasher.HashFile(outputPath, algorithms)
+	if err != nil {
+		diags.AddError(
+			"Unable to compute artifact hashes.",
+			"Reading '"+outputPath+"' failed with: '"+err.Error()+"'.",
+		)
+
+		return diags
+	}
+
+	sourceHashes, saltDiags := b.saltHashes(ctx, dirHashes, conf)
+	if diags.Append(saltDiags...); diags.HasError() {
+		return diags
+	}
+
+	b.GOOS = types.StringValue(conf.GetGOOS())
+	b.GOARCH = types.StringValue(conf.GetGOARCH())
+	b.OutputPath = types.StringValue(outputPath)
+	b.SetSourceHashes(sourceHashes)
+	b.setArtifactHashes(artifactHashes)
+	diags.Append(b.SetSourceManifest(ctx, sourceHashes.Files)...)
+
+	return diags
+}
+
+// compileErrorDiagnostics returns a diagnostic for each compile error.
+// The summary contains the location relative to the source and the first line of the message.
+func compileErrorDiagnostics(source string, errs []compiler.CompileError) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sourceDir := source
+	if strings.HasSuffix(source, ".go") {
+		sourceDir = filepath.Dir(source)
+	}
+
+	for _, compileErr := range errs {
+		location := compileErr
+		if absSourceDir, err := filepath.Abs(sourceDir); err == nil {
+			if relative, err := filepath.Rel(absSourceDir, compileErr.File); err == nil && !strings.HasPrefix(relative, "..") {
+				location.File = relative
+			}
+		}
+
+		summary, _, _ := strings.Cut(location.String(), "\n")
+		diags.AddError(
+			summary,
+			"Compiling package '"+compileErr.Package+"' failed at '"+compileErr.Location()+"' due '"+compileErr.Message+"'.",
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,456 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stevencyb/gopackager/internal/compiler"
+	"github.com/stevencyb/gopackager/internal/hasher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccBuildModelConfig(t *testing.T) {
+	t.Parallel()
+
+	defaults := &ProviderDefaults{
+		GOOS:          "linux",
+		GOARCH:        "amd64",
+		GoBinary:      "/usr/local/go/bin/go",
+		BuildFlags:    []string{"-v"},
+		Env:           map[string]string{"GOAMD64": "v2", "GOARM": "7"},
+		BuildCacheDir: "/tmp/gocache",
+		ArtifactDir:   "/tmp/artifacts",
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		t.Parallel()
+
+		model := BuildModel{
+			Source:      types.StringValue("main.go"),
+			Destination: types.StringValue("binary"),
+			GOOS:        types.StringNull(),
+			GOARCH:      types.StringNull(),
+		}
+
+		conf, diags := model.Config(context.Background(), defaults)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, compiler.NewConfig().
+			Source("main.go").
+			Destination(filepath.Join("/tmp/artifacts", "binary")).
+			GOOS("linux").
+			GOARCH("amd64").
+			GoBinary("/usr/local/go/bin/go").
+			CacheDir("/tmp/gocache").
+			BuildFlags([]string{"-v"}).
+			Env(map[string]string{"GOAMD64": "v2", "GOARM": "7"}), conf)
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		t.Parallel()
+
+		env, diags := types.MapValueFrom(context.Background(), types.StringType, map[string]string{"GOAMD64": "v3"})
+		assert.False(t, diags.HasError())
+		buildFlags, diags := types.ListValueFrom(context.Background(), types.StringType, []string{"-race"})
+		assert.False(t, diags.HasError())
+
+		model := BuildModel{
+			Source:      types.StringValue("main.go"),
+			Destination: types.StringValue("/opt/binary"),
+			GOOS:        types.StringValue("windows"),
+			GOARCH:      types.StringValue("arm64"),
+			Env:         env,
+			BuildFlags:  buildFlags,
+		}
+
+		conf, diags := model.Config(context.Background(), defaults)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "/opt/binary", conf.GetDestination())
+		assert.Equal(t, "windows", conf.GetGOOS())
+		assert.Equal(t, "arm64", conf.GetGOARCH())
+		assert.Equal(t, []string{"-race"}, conf.GetBuildFlags())
+		assert.Equal(t, map[string]string{"GOAMD64": "v3", "GOARM": "7"}, conf.GetEnv())
+		// The defaults are not modified by the merge.
+		assert.Equal(t, map[string]string{"GOAMD64": "v2", "GOARM": "7"}, defaults.Env)
+	})
+
+	t.Run("NoDefaults", func(t *testing.T) {
+		t.Parallel()
+
+		model := BuildModel{
+			Source:      types.StringValue("main.go"),
+			Destination: types.StringValue("binary"),
+			GOOS:        types.StringValue("linux"),
+			GOARCH:      types.StringValue("amd64"),
+		}
+
+		conf, diags := model.Config(context.Background(), nil)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, compiler.NewConfig().
+			Source("main.go").
+			Destination("binary").
+			GOOS("linux").
+			GOARCH("amd64"), conf)
+	})
+}
+
+func TestAccCompileErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	source := t.TempDir()
+	diags := compileErrorDiagnostics(filepath.Join(source, "main.go"), []compiler.CompileError{
+		{Package: "example.com/app", File: filepath.Join(source, "main.go"), Line: 7, Column: 2, Message: "undefined: foo"},
+		{Package: "example.com/app/sub", File: filepath.Join(source, "sub", "s.go"), Line: 3, Column: 19, Message: "too many return values\n\thave (number)\n\twant ()"},
+		{Package: "example.com/dep", File: "/elsewhere/dep.go", Line: 1, Message: "syntax error"},
+	})
+
+	assert.Len(t, diags, 3)
+	assert.Equal(t, "main.go:7:2: undefined: foo", diags[0].Summary())
+	assert.Equal(t, "Compiling package 'example.com/app' failed at '"+filepath.Join(source, "main.go")+":7:2' due 'undefined: foo'.", diags[0].Detail())
+	assert.Equal(t, filepath.Join("sub", "s.go")+":3:19: too many return values", diags[1].Summary())
+	assert.Contains(t, diags[1].Detail(), "\twant ()")
+	assert.Equal(t, "/elsewhere/dep.go:1: syntax error", diags[2].Summary())
+}
+
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccBuildModelSourceHashes(t *testing.T) {
+	compilerBackup, hasherBackup := globalCompiler, globalHasher
+	t.Cleanup(func() {
+		globalCompiler, globalHasher = compilerBackup, hasherBackup
+	})
+
+	globalCompiler = compiler.New()
+	globalHasher = hasher.New()
+
+	// A monorepo with two services, of which only `app` is built.
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		"app/go.mod":     "module example.com/app\n\ngo 1.21\n",
+		"app/main.go":    "package main\n\nfunc main() {}\n",
+		"app/README.md":  "readme",
+		"other/go.mod":   "module example.com/other\n\ngo 1.21\n",
+		"other/main.go":  "package main\n\nfunc main() {}\n",
+		"other/notes.md": "notes",
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0o600))
+	}
+
+	hashes := func(t *testing.T, hashMode string) string {
+		t.Helper()
+
+		model := BuildModel{
+			Source:      types.StringValue(filepath.Join(root, "app")),
+			Destination: types.StringValue(filepath.Join(t.TempDir(), "binary")),
+			GOOS:        types.StringValue("linux"),
+			GOARCH:      types.StringValue("amd64"),
+			BasePath:    types.StringValue(root),
+			HashMode:    types.StringValue(hashMode),
+		}
+
+		conf, diags := model.Config(context.Background(), nil)
+		assert.False(t, diags.HasError())
+
+		combinedHashes, diags := model.SourceHashes(context.Background(), nil, conf)
+		assert.False(t, diags.HasError(), diags)
+
+		return combinedHashes.SHA256
+	}
+
+	deps, dir := hashes(t, hashModeDeps), hashes(t, hashModeDir)
+
+	// Unrelated files only change the hashes of the whole directory.
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "app", "README.md"), []byte("changed"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "other", "main.go"), []byte("package main\n\nfunc main() { println() }\n"), 0o600))
+	assert.Equal(t, deps, hashes(t, hashModeDeps))
+	assert.NotEqual(t, dir, hashes(t, hashModeDir))
+
+	// Files of the binary change the hashes.
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "app", "main.go"), []byte("package main\n\nfunc main() { println() }\n"), 0o600))
+	assert.NotEqual(t, deps, hashes(t, hashModeDeps))
+}
+
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccBuildModelSourceHashesDepsOptions(t *testing.T) {
+	compilerBackup, hasherBackup := globalCompiler, globalHasher
+	t.Cleanup(func() {
+		globalCompiler, globalHasher = compilerBackup, hasherBackup
+	})
+
+	root := t.TempDir()
+	files := []string{filepath.Join(root, "main.go"), filepath.Join(root, "util.go")}
+
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("SourceFiles", mock.Anything, mock.Anything).Return(files, nil)
+	mockHasher := hasher.MockHasher{}
+	mockHasher.On("HashFiles", root, files, mock.Anything).Return(&hasher.CombinedHash{}, nil)
+	globalCompiler = &mockCompiler
+	globalHasher = &mockHasher
+
+	model := BuildModel{
+		Source:         types.StringValue(root),
+		BasePath:       types.StringValue(root),
+		Destination:    types.StringValue("binary"),
+		GOOS:           types.StringValue("linux"),
+		GOARCH:         types.StringValue("amd64"),
+		HashMode:       types.StringValue(hashModeDeps),
+		HashAlgorithms: types.ListValueMust(types.StringType, []attr.Value{types.StringValue(hasher.AlgorithmSHA256)}),
+	}
+	defaults := &ProviderDefaults{HashConcurrency: 3, HashCacheDir: t.TempDir()}
+
+	conf, diags := model.Config(context.Background(), defaults)
+	assert.False(t, diags.HasError())
+
+	_, diags = model.SourceHashes(context.Background(), defaults, conf)
+	assert.False(t, diags.HasError(), diags)
+
+	// The listed files are hashed with the concurrency and cache of the provider like the whole directory.
+	mockHasher.AssertCalled(t, "HashFiles", root, files, hasher.FilesOptions{
+		Concurrency: 3,
+		CacheDir:    defaults.HashCacheDir,
+		Algorithms:  []string{hasher.AlgorithmSHA256},
+	})
+}
+
+func TestAccBuildModelHashExcludes(t *testing.T) {
+	hasherBackup := globalHasher
+	t.Cleanup(func() {
+		globalHasher = hasherBackup
+	})
+
+	globalHasher = hasher.New()
+
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.21\n",
+		"main.go":    "package main\n\nfunc main() {}\n",
+		".gitignore": "*.log\n",
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0o600))
+	}
+
+	hashes := func(t *testing.T, model BuildModel) string {
+		t.Helper()
+
+		model.Source = types.StringValue(root)
+		model.Destination = types.StringValue(filepath.Join(root, "bin", "binary"))
+		model.GOOS = types.StringValue("linux")
+		model.GOARCH = types.StringValue("amd64")
+
+		conf, diags := model.Config(context.Background(), nil)
+		assert.False(t, diags.HasError())
+
+		combinedHashes, diags := model.SourceHashes(context.Background(), nil, conf)
+		assert.False(t, diags.HasError(), diags)
+
+		return combinedHashes.SHA256
+	}
+
+	// The directory of the destination exists after the first build.
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "bin"), 0o755))
+
+	initial := hashes(t, BuildModel{})
+	withExcludes := hashes(t, BuildModel{HashExcludes: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("dist/")})})
+	withoutIgnoreFiles := hashes(t, BuildModel{HashIgnore: types.ListValueMust(types.StringType, []attr.Value{})})
+
+	// The binary and its archive built into the base path don't change the hashes.
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "bin", "binary"), []byte("binary"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "bin", "binary.zip"), []byte("archive"), 0o600))
+	assert.Equal(t, initial, hashes(t, BuildModel{}))
+
+	// Ignored files only change the hashes without ignore files.
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "build.log"), []byte("log"), 0o600))
+	assert.Equal(t, initial, hashes(t, BuildModel{}))
+	assert.NotEqual(t, withoutIgnoreFiles, hashes(t, BuildModel{HashIgnore: types.ListValueMust(types.StringType, []attr.Value{})}))
+
+	// Excluded files only change the hashes without the excludes.
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "dist"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "dist", "bundle.js"), []byte("bundle"), 0o600))
+	assert.Equal(t, withExcludes, hashes(t, BuildModel{HashExcludes: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("dist/")})}))
+	assert.NotEqual(t, initial, hashes(t, BuildModel{}))
+}
+
+func TestAccBuildModelHashAlgorithms(t *testing.T) {
+	hasherBackup := globalHasher
+	t.Cleanup(func() {
+		globalHasher = hasherBackup
+	})
+
+	globalHasher = hasher.New()
+
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o600))
+
+	model := BuildModel{
+		Source:         types.StringValue(root),
+		Destination:    types.StringValue(filepath.Join(t.TempDir(), "binary")),
+		GOOS:           types.StringValue("linux"),
+		GOARCH:         types.StringValue("amd64"),
+		Tags:           types.ListValueMust(types.StringType, []attr.Value{types.StringValue("netgo")}),
+		HashAlgorithms: types.ListValueMust(types.StringType, []attr.Value{types.StringValue(hasher.AlgorithmCRC32C)}),
+	}
+
+	conf, diags := model.Config(context.Background(), nil)
+	assert.False(t, diags.HasError())
+
+	// The build settings are salted into the selected algorithms only.
+	combinedHashes, diags := model.SourceHashes(context.Background(), nil, conf)
+	assert.False(t, diags.HasError(), diags)
+	assert.Len(t, combinedHashes.Hashes, 2)
+	assert.Len(t, combinedHashes.Hashes[hasher.AlgorithmCRC32C], 8)
+
+	model.SetSourceHashes(combinedHashes)
+	assert.True(t, model.OutputSHA256.IsNull())
+	assert.Equal(t, types.StringValue(combinedHashes.Hashes[hasher.AlgorithmCRC32C]), model.OutputHashes.Elements()[hasher.AlgorithmCRC32C])
+	assert.Contains(t, model.OutputHashes.Elements(), hasher.AlgorithmCRC32C+hasher.Base64Suffix)
+}
+
+func TestAccBuildModelSourceManifest(t *testing.T) {
+	hasherBackup := globalHasher
+	t.Cleanup(func() {
+		globalHasher = hasherBackup
+	})
+
+	globalHasher = hasher.New()
+
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "cmd"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "cmd", "run.sh"), []byte("#!/bin/sh\n"), 0o755))
+
+	model := BuildModel{
+		Source:                types.StringValue(root),
+		BasePath:              types.StringValue(root),
+		Destination:           types.StringValue(filepath.Join(t.TempDir(), "binary")),
+		GOOS:                  types.StringValue("linux"),
+		GOARCH:                types.StringValue("amd64"),
+		Tags:                  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("netgo")}),
+		SourceManifestEnabled: types.BoolValue(true),
+		SourceManifestFile:    types.StringValue(filepath.Join(t.TempDir(), "manifest", "source.json")),
+	}
+
+	conf, diags := model.Config(context.Background(), nil)
+	assert.False(t, diags.HasError())
+
+	// The salted hashes keep the hashed files.
+	combinedHashes, diags := model.SourceHashes(context.Background(), nil, conf)
+	assert.False(t, diags.HasError(), diags)
+	assert.Len(t, combinedHashes.Files, 2)
+
+	assert.False(t, model.SetSourceManifest(context.Background(), combinedHashes.Files).HasError())
+
+	manifest := map[string]ManifestEntryModel{}
+	assert.False(t, model.SourceManifest.ElementsAs(context.Background(), &manifest, false).HasError())
+	assert.Equal(t, ManifestEntryModel{
+		Digest: types.StringValue(hex.EncodeToString(combinedHashes.Files[0].Digest)),
+		Size:   types.Int64Value(10),
+		Mode:   types.StringValue("0755"),
+	}, manifest["cmd/run.sh"])
+	assert.Equal(t, types.Int64Value(29), manifest["main.go"].Size)
+	assert.Equal(t, types.StringValue("0600"), manifest["main.go"].Mode)
+
+	assert.False(t, model.writeSourceManifest(combinedHashes.Files).HasError())
+
+	content, err := os.ReadFile(model.SourceManifestFile.ValueString())
+	assert.NoError(t, err)
+
+	written := manifestFile{}
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, []manifestFileEntry{
+		{Path: "cmd/run.sh", Digest: manifest["cmd/run.sh"].Digest.ValueString(), Size: 10, Mode: "0755"},
+		{Path: "main.go", Digest: manifest["main.go"].Digest.ValueString(), Size: 29, Mode: "0600"},
+	}, written.Files)
+
+	model.SourceManifestEnabled = types.BoolNull()
+	assert.False(t, model.SetSourceManifest(context.Background(), combinedHashes.Files).HasError())
+	assert.True(t, model.SourceManifest.IsNull())
+}
+
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccBuildModelSourceManifestInBasePath(t *testing.T) {
+	compilerBackup, hasherBackup := globalCompiler, globalHasher
+	t.Cleanup(func() {
+		globalCompiler, globalHasher = compilerBackup, hasherBackup
+	})
+
+	root := t.TempDir()
+	destination := filepath.Join(t.TempDir(), "binary")
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o600))
+
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Compile", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		assert.NoError(t, os.WriteFile(destination, []byte("binary"), 0o600))
+	}).Return(destination, nil)
+	globalCompiler = &mockCompiler
+	globalHasher = hasher.New()
+
+	build := func() BuildModel {
+		model := BuildModel{
+			Source:                types.StringValue(filepath.Join(root, "main.go")),
+			Destination:           types.StringValue(destination),
+			GOOS:                  types.StringValue("linux"),
+			GOARCH:                types.StringValue("amd64"),
+			SourceManifestEnabled: types.BoolValue(true),
+			SourceManifestFile:    types.StringValue(filepath.Join(root, "manifest.json")),
+		}
+		diags := model.Build(context.Background(), nil, "")
+		assert.False(t, diags.HasError(), diags)
+
+		return model
+	}
+
+	// The manifest written into the source tree by the first build must not change the hashes of the next one.
+	first := build()
+	assert.FileExists(t, filepath.Join(root, "manifest.json"))
+	second := build()
+	assert.Equal(t, first.OutputHashes, second.OutputHashes)
+	assert.Equal(t, first.SourceManifest, second.SourceManifest)
+}
+
+func TestAccBuildModelDirOptions(t *testing.T) {
+	t.Parallel()
+
+	model := BuildModel{
+		HashExcludes: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("dist/")}),
+		HashIgnore:   types.ListNull(types.StringType),
+	}
+	conf := compiler.NewConfig().Destination("bin/binary")
+
+	t.Run("Defaults", func(t *testing.T) {
+		t.Parallel()
+
+		opts, diags := model.dirOptions(context.Background(), nil, conf)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, hasher.DirOptions{
+			IgnoreFiles:  defaultHashIgnoreFiles,
+			Excludes:     []string{"dist/"},
+			ExcludePaths: []string{"bin/binary", "bin/binary.zip", "bin/binary.tar.gz", "bin/binary.tar.zst"},
+		}, opts)
+	})
+
+	t.Run("Source_Manifest", func(t *testing.T) {
+		t.Parallel()
+
+		manifestModel := model
+		manifestModel.SourceManifestFile = types.StringValue("dist/manifest.json")
+
+		opts, diags := manifestModel.dirOptions(context.Background(), nil)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, []string{"dist/manifest.json"}, opts.ExcludePaths)
+	})
+
+	t.Run("Provider_Defaults", func(t *testing.T) {
+		t.Parallel()
+
+		opts, diags := model.dirOptions(context.Background(), &ProviderDefaults{HashConcurrency: 4, HashCacheDir: "/tmp/hashes"})
+		assert.False(t, diags.HasError())
+		assert.Equal(t, 4, opts.Concurrency)
+		assert.Equal(t, "/tmp/hashes", opts.CacheDir)
+	})
+}
@@ -3,54 +3,48 @@ package provider
 import (
 	"context"
 	"fmt"
-	"path/filepath"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/stevencyb/gopackager/internal/compiler"
 	"github.com/stevencyb/gopackager/internal/hasher"
 	"github.com/stevencyb/gopackager/internal/packager"
 )
 
-// This is the global compiler instance.
-// This instance is replaced by the mock instance during tests.
-var globalCompiler compiler.CompilerI = compiler.New()
-
-// This is the global ZIP packager instance.
-// This instance is replaced by the mock instance during tests.
-var globalZIPPackager packager.ZIPI = packager.New()
-
-// This is the global hasher instance.
-// This instance is replaced by the mock instance during tests.
-var globalHasher hasher.HasherI = hasher.New()
-
 // CompileDataSourceModel is the model for the compile data source.
 type CompileDataSourceModel struct {
-	// Input
-	Source      types.String `tfsdk:"source"`
-	Destination types.String `tfsdk:"destination"`
-	GOOS        types.String `tfsdk:"goos"`
-	GOARCH      types.String `tfsdk:"goarch"`
-	// Optional
-	ZIP          types.Bool   `tfsdk:"zip"`
-	ZIPResources types.Map    `tfsdk:"zip_resources"`
-	BasePath     types.String `tfsdk:"base_path"`
-	// Output
-	OutputPath         types.String `tfsdk:"output_path"`
-	OutputMD5          types.String `tfsdk:"output_md5"`
-	OutputSHA1         types.String `tfsdk:"output_sha1"`
-	OutputSHA256       types.String `tfsdk:"output_sha256"`
-	OutputSHA512       types.String `tfsdk:"output_sha512"`
-	OutputSHA256Base64 types.String `tfsdk:"output_sha256_base64"`
-	OutputSHA512Base64 types.String `tfsdk:"output_sha512_base64"`
+	BuildModel
+	ZIP           types.Bool                   `tfsdk:"zip"`
+	Targets       []TargetModel                `tfsdk:"targets"`
+	Concurrency   types.Int64                  `tfsdk:"concurrency"`
+	TargetOutputs map[string]TargetOutputModel `tfsdk:"target_outputs"`
+	Timeouts      timeouts.Value               `tfsdk:"timeouts"`
+}
+
+// archiveFormat returns the archive format, which is empty if no archive is created.
+func (c *CompileDataSourceModel) archiveFormat() string {
+	archiveFormat := c.ArchiveFormat.ValueString()
+	if archiveFormat == "" && c.ZIP.ValueBool() {
+		archiveFormat = packager.FormatZIP
+	}
+
+	return archiveFormat
 }
 
 // CompileDataSource is the data source for the compile resource.
-type CompileDataSource struct{}
+type CompileDataSource struct {
+	defaults *ProviderDefaults
+}
 
 // New creates a new data source instance.
 func NewCompilerDataSource() datasource.DataSource {
@@ -64,7 +58,7 @@ func (c *CompileDataSource) Metadata(ctx context.Context, req datasource.Metadat
 
 // Sets the provider schema.
 func (c *CompileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	description := `Compiles GoLang source code into a binary executable and optionally creates a ZIP with additional files.` +
+	description := `Compiles GoLang source code into a binary executable and optionally creates an archive (ZIP, tar.gz or tar.zst) with additional files.` +
 		` This resource requires GoLang to be installed on the system.` +
 		` The resource will automatically download the required dependencies and compile the source code.`
 
@@ -79,35 +73,165 @@ func (c *CompileDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Required:            true,
 			},
 			"destination": schema.StringAttribute{
-				MarkdownDescription: "Path for the compiled binary (or random UUID).",
+				MarkdownDescription: "Path for the compiled binary (or random UUID). Relative paths are resolved against the `artifact_dir` of the provider if set.",
 				Required:            true,
 			},
 			"goos": schema.StringAttribute{
-				MarkdownDescription: "GOOS for the compiled binary.",
-				Required:            true,
+				MarkdownDescription: "GOOS for the compiled binary. Defaults to the `goos` of the provider, one of both must be set.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"goarch": schema.StringAttribute{
-				MarkdownDescription: "GOARCH for the compiled binary.",
-				Required:            true,
+				MarkdownDescription: "GOARCH for the compiled binary. Defaults to the `goarch` of the provider, one of both must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"targets": schema.ListNestedAttribute{
+				MarkdownDescription: "Build matrix to compile the binary for multiple targets in parallel instead of a single `goos` and `goarch`. " +
+					"Each binary is placed in a directory named by the target next to the destination (e.g. `dist/cli` results in `dist/linux_amd64/cli`). " +
+					"The outputs are provided by `target_outputs` instead of `output_path` and `artifact_*`.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"goos": schema.StringAttribute{
+							MarkdownDescription: "GOOS of the target.",
+							Required:            true,
+						},
+						"goarch": schema.StringAttribute{
+							MarkdownDescription: "GOARCH of the target.",
+							Required:            true,
+						},
+						"variant": schema.StringAttribute{
+							MarkdownDescription: "Variant of the GOARCH (e.g. `v3` for `amd64` or `7` for `arm`), which is set as `GOAMD64`, `GOARM`, `GO386`, `GOARM64`, `GOMIPS`, `GOMIPS64`, `GOPPC64`, `GORISCV64` or `GOWASM`.",
+							Optional:            true,
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"concurrency": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of targets to build in parallel. Defaults to the number of CPUs.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			// Output input
 			"zip": schema.BoolAttribute{
-				MarkdownDescription: "Zip the compiled binary and additional resources.",
+				MarkdownDescription: "Zip the compiled binary and additional resources. Alias for `archive_format = \"zip\"`.",
+				DeprecationMessage:  "Use `archive_format = \"zip\"` instead.",
 				Optional:            true,
 			},
+			"archive_format": schema.StringAttribute{
+				MarkdownDescription: "Archive the compiled binary and additional resources. Supported formats are `zip`, `tar.gz` and `tar.zst`. " +
+					"The format is appended as extension to the destination.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(packager.Formats()...),
+				},
+			},
 			"zip_resources": schema.MapAttribute{
-				MarkdownDescription: "Additional resources to include in the zip file. The binary is automatically included an copied to the root of the zip file.",
-				Optional:            true,
-				ElementType:         types.StringType,
+				MarkdownDescription: "Additional resources to include in the archive. The binary is automatically included an copied to the root of the archive. " +
+					"Sources can be glob patterns (e.g. `templates/**/*.tmpl`), which keep the path of matched files relative to the part before the first wildcard below the archive path.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"zip_file_modes": schema.MapAttribute{
+				MarkdownDescription: "Overwrite the permissions of files inside of the archive by their path inside of the archive (e.g. `bootstrap = \"0755\"`). " +
+					"Files without an entry keep the permissions they have on the file system.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(fileModePattern, "must be an octal file permission like \"0755\""),
+					),
+				},
+			},
+			"zip_excludes": schema.ListAttribute{
+				MarkdownDescription: "Glob patterns of paths inside of the archive to exclude (e.g. `**/.DS_Store` or `**/*_test.go`). " +
+					"A pattern matching a directory excludes all files inside of it. Files listed in `zip_resources` without a pattern are always included.",
+				Optional:    true,
+				ElementType: types.StringType,
 			},
 			"base_path": schema.StringAttribute{
 				MarkdownDescription: "Overwrite the base path to watch that is by default the source directory.",
 				Optional:            true,
 			},
+			"hash_mode": schema.StringAttribute{
+				MarkdownDescription: "Files of the base path that are hashed for `output_*`. " +
+					"`dir` (default) hashes all files. `deps` hashes only the files the binary is built from according to `go list -deps`: " +
+					"the Go and embedded files of the packages of the main module and of modules replaced by a local directory as well as their `go.mod` and `go.sum`, " +
+					"so changes to unrelated files no longer change the hashes.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(hashModeDir, hashModeDeps),
+				},
+			},
+			"hash_excludes": schema.ListAttribute{
+				MarkdownDescription: "Patterns in the format of `.gitignore` relative to the base path of files to exclude from the hashes with `hash_mode = \"dir\"` (e.g. `dist/` or `*.log`). " +
+					"Unlike the patterns of ignore files they can't be negated. The `destination` and its archives are always excluded.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"hash_ignore_files": schema.ListAttribute{
+				MarkdownDescription: "Names of ignore files whose patterns exclude files from the hashes with `hash_mode = \"dir\"`, defaults to `[\".gitignore\", \".dockerignore\"]`. " +
+					"Ignore files apply to their directory like a `.gitignore`, except for `.dockerignore` that only applies in the base path with patterns relative to it. " +
+					"Set to `[]` to hash ignored files as well.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"hash_algorithms": schema.ListAttribute{
+				MarkdownDescription: "Algorithms of the hashes in `output_hashes` and `artifact_hashes`, defaults to `[\"md5\", \"sha1\", \"sha256\", \"sha512\"]`. " +
+					"Supported are `" + strings.Join(hasher.Algorithms(), "`, `") + "`. " +
+					"Only the selected algorithms are computed, so the `output_*` and `artifact_*` attributes of other algorithms are null. " +
+					"The source files are hashed with `sha512` if selected, otherwise with the strongest selected of `sha3_512`, `blake2b_512`, `sha3_256`, `blake2b_256` and `sha256` or else with `sha512`, and their digests are combined with each selected algorithm.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(hasher.Algorithms()...)),
+				},
+			},
+			"source_manifest_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Set `source_manifest` to the hashed source files, so that `terraform plan` shows which files changed.",
+				Optional:            true,
+			},
+			"source_manifest_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a JSON file the hashed source files are written to after the build, relative to the working directory. The file itself is never hashed.",
+				Optional:            true,
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Build tags passed to `go build -tags`. Changing the tags changes the output hashes.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"cgo_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Set `CGO_ENABLED` for the build. If not set, the Go default applies regardless of the host environment.",
+				Optional:            true,
+			},
+			"env": schema.MapAttribute{
+				MarkdownDescription: "Additional environment variables for the build (e.g. `GOAMD64`). " +
+					"GO* variables of the host are not passed to the build unless they only configure paths, caches, proxies or private modules.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"reproducible": schema.BoolAttribute{
+				MarkdownDescription: "Build a byte-identical binary for the same source on every machine. " +
+					"Applies `-trimpath`, `-buildvcs=false` and an empty build ID and ignores `GOFLAGS` and the go env file of the host.",
+				Optional: true,
+			},
+			"build_flags": schema.ListAttribute{
+				MarkdownDescription: "Additional flags passed to `go build` (e.g. `-v`), which replace the `build_flags` of the provider.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
 			// Output
 			"output_path": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Output path for the compiled binary or compressed ZIP file.",
+				MarkdownDescription: "Output path for the compiled binary or archive.",
 			},
 			"output_md5": schema.StringAttribute{
 				Computed:            true,
@@ -133,6 +257,125 @@ func (c *CompileDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:            true,
 				MarkdownDescription: "Base64 encoded SHA512 hash of the source files.",
 			},
+			"output_hashes": schema.MapAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hashes of the source files by algorithm of `hash_algorithms` as hex and by algorithm with the suffix `_base64` base64 encoded (e.g. `crc32c_base64`).",
+				ElementType:         types.StringType,
+			},
+			"source_manifest": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hashed source files by their path relative to the base path if `source_manifest_enabled` is set.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"digest": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Hash of the path and content of the file or the target of the symlink with the algorithm the source files are hashed with (see `hash_algorithms`).",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size of the file in bytes.",
+						},
+						"mode": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Permissions of the file like `0644`.",
+						},
+					},
+				},
+			},
+			"target_outputs": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Outputs of the `targets` by the target like `linux_amd64` or `linux_arm_7`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"output_path": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Output path for the compiled binary or archive of the target.",
+						},
+						"artifact_md5": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "MD5 hash of the compiled binary or archive of the target.",
+						},
+						"artifact_sha1": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SHA1 hash of the compiled binary or archive of the target.",
+						},
+						"artifact_sha256": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SHA256 hash of the compiled binary or archive of the target.",
+						},
+						"artifact_sha512": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SHA512 hash of the compiled binary or archive of the target.",
+						},
+						"artifact_sha256_base64": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Base64 encoded SHA256 hash of the compiled binary or archive of the target.",
+						},
+						"artifact_sha512_base64": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Base64 encoded SHA512 hash of the compiled binary or archive of the target.",
+						},
+						"artifact_hashes": schema.MapAttribute{
+							Computed:            true,
+							MarkdownDescription: "Hashes of the compiled binary or archive of the target by algorithm of `hash_algorithms` as hex and by algorithm with the suffix `_base64` base64 encoded (e.g. `crc32c_base64`).",
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			// Artifact output
+			"artifact_md5": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MD5 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha1": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA1 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha512": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA512 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha256_base64": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Base64 encoded SHA256 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_sha512_base64": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Base64 encoded SHA512 hash of the compiled binary or archive at `output_path`.",
+			},
+			"artifact_hashes": schema.MapAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hashes of the compiled binary or archive at `output_path` by algorithm of `hash_algorithms` as hex and by algorithm with the suffix `_base64` base64 encoded (e.g. `crc32c_base64`).",
+				ElementType:         types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockWithOpts(ctx, timeouts.Opts{
+				ReadDescription: "Time the build may take (e.g. `30s` or `1h`), including the download of modules. Defaults to `20m`.",
+			}),
+			"ldflags": schema.SingleNestedBlock{
+				MarkdownDescription: "Linker flags passed to `go build -ldflags`.",
+				Attributes: map[string]schema.Attribute{
+					"variables": schema.MapAttribute{
+						MarkdownDescription: "String variables to set via `-X importpath.name=value` (e.g. `main.version = \"v1.0.0\"`).",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"strip_symbols": schema.BoolAttribute{
+						MarkdownDescription: "Omit the symbol table and debug information (`-s`).",
+						Optional:            true,
+					},
+					"strip_dwarf": schema.BoolAttribute{
+						MarkdownDescription: "Omit the DWARF symbol table (`-w`).",
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -142,6 +385,18 @@ func (c *CompileDataSource) Configure(ctx context.Context, req datasource.Config
 	if req.ProviderData == nil {
 		return
 	}
+
+	defaults, ok := req.ProviderData.(*ProviderDefaults)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data.",
+			fmt.Sprintf("Expected *ProviderDefaults, but got '%T'.", req.ProviderData),
+		)
+
+		return
+	}
+
+	c.defaults = defaults
 }
 
 // Read event for this data source.
@@ -153,76 +408,86 @@ func (c *CompileDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	tflog.Trace(ctx, "Checking configuration")
+	timeout, diags := data.Timeouts.Read(ctx, defaultBuildTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
 
-	conf := compiler.NewConfig().
-		Source(data.Source.ValueString()).
-		Destination(data.Destination.ValueString()).
-		GOOS(data.GOOS.ValueString()).
-		GOARCH(data.GOARCH.ValueString())
-	if err := conf.Verify(); err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid configuration.",
-			"Expected configuration to be valid, but got '"+err.Error()+"'.",
-		)
+	// Validate the platforms with the go binary of the provider, which `ValidateConfig` may run without.
+	goBinary := providerGoBinary(c.defaults)
+	resp.Diagnostics.Append(validatePlatform(ctx, goBinary, data.GOOS, data.GOARCH, fwpath.Root("goos"), fwpath.Root("goarch"))...)
+	if len(data.Targets) == 0 {
+		resp.Diagnostics.Append(validatePlatformResolvable(c.defaults, data.GOOS, data.GOARCH, fwpath.Root("goos"), fwpath.Root("goarch"))...)
+	}
+	if resp.Diagnostics.Append(validateTargetPlatforms(ctx, goBinary, data.Targets)...); resp.Diagnostics.HasError() {
+		return
 	}
 
-	tflog.Trace(ctx, "Compiling GoLang source code")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	outputPath, err := globalCompiler.Compile(*conf)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to compile binary.",
-			"Compiling go code failed due '"+err.Error()+"'.",
-		)
+	if len(data.Targets) > 0 {
+		resp.Diagnostics.Append(data.BuildTargets(ctx, c.defaults)...)
+	} else {
+		resp.Diagnostics.Append(data.Build(ctx, c.defaults, data.archiveFormat())...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ValidateConfig validates GOOS and GOARCH against the ports of the toolchain and that they are set or defaulted.
+// The validation is skipped until the provider is configured, as the go binary and the defaults of the provider
+// are unknown before, and repeated by `Read` once it is.
+func (c *CompileDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	if c.defaults == nil {
 		return
 	}
 
-	if !data.ZIP.IsNull() && !data.ZIP.IsUnknown() && data.ZIP.ValueBool() {
-		additionalFiles := map[string]string{}
-		if !data.ZIPResources.IsNull() && !data.ZIPResources.IsUnknown() {
-			if diag := data.ZIPResources.ElementsAs(ctx, &additionalFiles, false); diag.HasError() {
-				resp.Diagnostics.Append(diag...)
-			}
-		}
+	var goos, goarch types.String
+	var targets types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, fwpath.Root("goos"), &goos)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, fwpath.Root("goarch"), &goarch)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, fwpath.Root("targets"), &targets)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		tflog.Trace(ctx, fmt.Sprintf("Zipping compiled binary with %d additional files", len(additionalFiles)))
+	resp.Diagnostics.Append(validatePlatform(ctx, c.defaults.GoBinary, goos, goarch, fwpath.Root("goos"), fwpath.Root("goarch"))...)
 
-		additionalFiles[outputPath] = filepath.Base(outputPath)
-		outputPath += ".zip"
+	// GOOS and GOARCH are only used without targets, which set their own.
+	if targets.IsNull() {
+		resp.Diagnostics.Append(validatePlatformResolvable(c.defaults, goos, goarch, fwpath.Root("goos"), fwpath.Root("goarch"))...)
 
-		if err = globalZIPPackager.Zip(outputPath, additionalFiles); err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to create ZIP file.",
-				"ZIP failed with: '"+err.Error()+"'.",
-			)
-		}
+		return
 	}
 
-	tflog.Trace(ctx, "Compute hashes")
-	baseTriggerPath := filepath.Dir(data.Source.ValueString())
-	if !data.BasePath.IsNull() && !data.BasePath.IsUnknown() {
-		baseTriggerPath = data.BasePath.ValueString()
+	if targets.IsUnknown() {
+		return
 	}
 
-	combinedHashes, err := globalHasher.HashDir(baseTriggerPath)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to compute hashes.",
-			"Hashing failed with: '"+err.Error()+"'.",
-		)
+	targetModels := []TargetModel{}
+	if resp.Diagnostics.Append(targets.ElementsAs(ctx, &targetModels, false)...); resp.Diagnostics.HasError() {
+		return
 	}
 
-	data.OutputPath = types.StringValue(outputPath)
-	data.OutputMD5 = types.StringValue(combinedHashes.MD5)
-	data.OutputSHA1 = types.StringValue(combinedHashes.SHA1)
-	data.OutputSHA256 = types.StringValue(combinedHashes.SHA256)
-	data.OutputSHA512 = types.StringValue(combinedHashes.SHA512)
-	data.OutputSHA256Base64 = types.StringValue(combinedHashes.SHA256Base64)
-	data.OutputSHA512Base64 = types.StringValue(combinedHashes.SHA512Base64)
+	resp.Diagnostics.Append(validateTargetPlatforms(ctx, c.defaults.GoBinary, targetModels)...)
+}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// validateTargetPlatforms validates GOOS and GOARCH of the targets against the ports of the toolchain.
+func validateTargetPlatforms(ctx context.Context, goBinary string, targets []TargetModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for i, target := range targets {
+		targetPath := fwpath.Root("targets").AtListIndex(i)
+		diags.Append(validatePlatform(ctx, goBinary, target.GOOS, target.GOARCH, targetPath.AtName("goos"), targetPath.AtName("goarch"))...)
+	}
+
+	return diags
 }
 
 // ConfigValidators returns the config validators for this data source.
@@ -231,8 +496,49 @@ func (c *CompileDataSource) ConfigValidators(context.Context) []datasource.Confi
 		datasourcevalidator.RequiredTogether(
 			fwpath.MatchRoot("source"),
 			fwpath.MatchRoot("destination"),
+		),
+		zipArchiveFormatValidator{},
+		datasourcevalidator.Conflicting(
+			fwpath.MatchRoot("targets"),
 			fwpath.MatchRoot("goos"),
+		),
+		datasourcevalidator.Conflicting(
+			fwpath.MatchRoot("targets"),
 			fwpath.MatchRoot("goarch"),
 		),
 	}
 }
+
+// zipArchiveFormatValidator rejects `archive_format` if the deprecated `zip` is set to true,
+// so `zip = false` can be combined with any archive format.
+type zipArchiveFormatValidator struct{}
+
+// Description describes the validation.
+func (v zipArchiveFormatValidator) Description(ctx context.Context) string {
+	return "archive_format conflicts with zip = true"
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v zipArchiveFormatValidator) MarkdownDescription(ctx context.Context) string {
+	return "`archive_format` conflicts with `zip = true`"
+}
+
+// ValidateDataSource validates the config of the data source.
+func (v zipArchiveFormatValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var zip types.Bool
+	var archiveFormat types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, fwpath.Root("zip"), &zip)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, fwpath.Root("archive_format"), &archiveFormat)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if zip.ValueBool() && !archiveFormat.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			fwpath.Root("archive_format"),
+			"Invalid attribute combination.",
+			"Attribute 'archive_format' can't be set together with 'zip = true', use 'archive_format = \"zip\"' instead.",
+		)
+	}
+}
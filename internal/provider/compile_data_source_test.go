@@ -3,35 +3,196 @@ package provider
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"regexp"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stevencyb/gopackager/internal/compiler"
 	"github.com/stevencyb/gopackager/internal/hasher"
 	"github.com/stevencyb/gopackager/internal/packager"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+// testPorts are the ports of the mocked toolchain.
+var testPorts = compiler.Ports{
+	{GOOS: "linux", GOARCH: "amd64"},
+	{GOOS: "linux", GOARCH: "arm"},
+	{GOOS: "linux", GOARCH: "arm64"},
+	{GOOS: "linux", GOARCH: "s390x"},
+	{GOOS: "darwin", GOARCH: "arm64"},
+	{GOOS: "windows", GOARCH: "amd64"},
+}
+
 func TestAccDataSourceFrameworkSatisfaction(t *testing.T) {
 	t.Parallel()
 
 	var _ datasource.DataSource = &CompileDataSource{}
+	var _ datasource.DataSourceWithValidateConfig = &CompileDataSource{}
+	var _ datasource.DataSourceWithConfigValidators = &CompileDataSource{}
+	var _ datasource.ConfigValidator = zipArchiveFormatValidator{}
+}
+
+// testConfigValue creates the raw config of the schema type with the given attributes, all others are null.
+func testConfigValue(t *testing.T, schemaType attr.Type, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	objectType, ok := schemaType.TerraformType(context.Background()).(tftypes.Object)
+	assert.True(t, ok)
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	return tftypes.NewValue(objectType, attributes)
+}
+
+// testDataSourceConfig creates the config of the data source with the given attributes, all others are null.
+func testDataSourceConfig(t *testing.T, dataSource datasource.DataSource, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	schemaResp := &datasource.SchemaResponse{}
+	dataSource.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError())
+
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: testConfigValue(t, schemaResp.Schema.Type(), values)}
+}
+
+// The test replaces the global compiler and can therefore not run in parallel.
+func TestAccCompileDataSourcePlatformValidation(t *testing.T) {
+	compilerBackup := globalCompiler
+	t.Cleanup(func() {
+		globalCompiler = compilerBackup
+	})
+
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Ports", mock.Anything, "go1.24").Return(testPorts, nil)
+	globalCompiler = &mockCompiler
+
+	config := testDataSourceConfig(t, NewCompilerDataSource(), map[string]tftypes.Value{
+		"source":      tftypes.NewValue(tftypes.String, "main.go"),
+		"destination": tftypes.NewValue(tftypes.String, "binary"),
+		"goos":        tftypes.NewValue(tftypes.String, "linuxx"),
+	})
+
+	// The go binary of the provider is unknown before the provider is configured.
+	unconfigured := &CompileDataSource{}
+	validateResp := &datasource.ValidateConfigResponse{}
+	unconfigured.ValidateConfig(context.Background(), datasource.ValidateConfigRequest{Config: config}, validateResp)
+	assert.False(t, validateResp.Diagnostics.HasError())
+	mockCompiler.AssertNotCalled(t, "Ports", mock.Anything, mock.Anything)
+
+	configured := &CompileDataSource{defaults: &ProviderDefaults{GoBinary: "go1.24"}}
+	configured.ValidateConfig(context.Background(), datasource.ValidateConfigRequest{Config: config}, validateResp)
+	assert.True(t, validateResp.Diagnostics.HasError())
+
+	// Read validates again with the go binary of the provider before building.
+	readResp := &datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema}}
+	configured.Read(context.Background(), datasource.ReadRequest{Config: config}, readResp)
+	assert.True(t, readResp.Diagnostics.HasError())
+	assert.Contains(t, readResp.Diagnostics.Errors()[0].Detail(), "Did you mean 'linux'?")
+	mockCompiler.AssertCalled(t, "Ports", mock.Anything, "go1.24")
+	mockCompiler.AssertNotCalled(t, "Compile", mock.Anything, mock.Anything)
+}
+
+// The test replaces the global compiler and can therefore not run in parallel.
+func TestAccCompileDataSourcePlatformResolution(t *testing.T) {
+	compilerBackup := globalCompiler
+	t.Cleanup(func() {
+		globalCompiler = compilerBackup
+	})
+
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Ports", mock.Anything, mock.Anything).Return(testPorts, nil)
+	globalCompiler = &mockCompiler
+
+	dataSource := &CompileDataSource{defaults: &ProviderDefaults{}}
+	values := map[string]tftypes.Value{
+		"source":      tftypes.NewValue(tftypes.String, "main.go"),
+		"destination": tftypes.NewValue(tftypes.String, "binary"),
+	}
+
+	validateResp := &datasource.ValidateConfigResponse{}
+	dataSource.ValidateConfig(context.Background(), datasource.ValidateConfigRequest{Config: testDataSourceConfig(t, dataSource, values)}, validateResp)
+	assert.Len(t, validateResp.Diagnostics.Errors(), 2)
+	assert.Equal(t, "Missing GOOS.", validateResp.Diagnostics.Errors()[0].Summary())
+	assert.Equal(t, "Missing GOARCH.", validateResp.Diagnostics.Errors()[1].Summary())
+
+	// The targets set their own GOOS and GOARCH.
+	targetType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"goos": tftypes.String, "goarch": tftypes.String, "variant": tftypes.String}}
+	values["targets"] = tftypes.NewValue(tftypes.List{ElementType: targetType}, []tftypes.Value{
+		tftypes.NewValue(targetType, map[string]tftypes.Value{
+			"goos":    tftypes.NewValue(tftypes.String, "linux"),
+			"goarch":  tftypes.NewValue(tftypes.String, "amd64"),
+			"variant": tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+
+	validateResp = &datasource.ValidateConfigResponse{}
+	dataSource.ValidateConfig(context.Background(), datasource.ValidateConfigRequest{Config: testDataSourceConfig(t, dataSource, values)}, validateResp)
+	assert.False(t, validateResp.Diagnostics.HasError(), validateResp.Diagnostics)
+}
+
+func TestAccZIPArchiveFormatValidator(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		zip           tftypes.Value
+		archiveFormat tftypes.Value
+		expectError   bool
+	}{
+		"Zip_True_With_Archive_Format":  {tftypes.NewValue(tftypes.Bool, true), tftypes.NewValue(tftypes.String, "tar.gz"), true},
+		"Zip_True_With_Unknown_Format":  {tftypes.NewValue(tftypes.Bool, true), tftypes.NewValue(tftypes.String, tftypes.UnknownValue), true},
+		"Zip_False_With_Archive_Format": {tftypes.NewValue(tftypes.Bool, false), tftypes.NewValue(tftypes.String, "tar.gz"), false},
+		"Zip_Unknown_With_Format":       {tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue), tftypes.NewValue(tftypes.String, "zip"), false},
+		"Zip_True_Only":                 {tftypes.NewValue(tftypes.Bool, true), tftypes.NewValue(tftypes.String, nil), false},
+		"Archive_Format_Only":           {tftypes.NewValue(tftypes.Bool, nil), tftypes.NewValue(tftypes.String, "tar.zst"), false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := datasource.ValidateConfigRequest{Config: testDataSourceConfig(t, NewCompilerDataSource(), map[string]tftypes.Value{
+				"zip":            tc.zip,
+				"archive_format": tc.archiveFormat,
+			})}
+			resp := &datasource.ValidateConfigResponse{}
+
+			zipArchiveFormatValidator{}.ValidateDataSource(context.Background(), req, resp)
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
 }
 
 func TestAccCompileDataSource(t *testing.T) {
 	t.Parallel()
 
 	mockCompiler := compiler.MockCompiler{}
-	mockPackager := packager.MockZIP{}
+	mockZIPPackager := packager.MockPackager{}
+	mockTarGzPackager := packager.MockPackager{}
 	mockHasher := hasher.MockHasher{}
 	globalCompiler = &mockCompiler
-	globalZIPPackager = &mockPackager
+	globalPackagers = map[string]packager.Packager{
+		packager.FormatZIP:   &mockZIPPackager,
+		packager.FormatTarGz: &mockTarGzPackager,
+	}
 	globalHasher = &mockHasher
+	mockCompiler.On("Ports", mock.Anything, "").Return(testPorts, nil)
 	testAccProtoV6ProviderFactories := map[string]func() (tfprotov6.ProviderServer, error){
 		"gopackager": providerserver.NewProtocol6WithError(New("test")()),
 	}
@@ -43,82 +204,210 @@ func TestAccCompileDataSource(t *testing.T) {
 	additionalZIPResourcesGen, diag := types.MapValueFrom(context.Background(), types.StringType, additionalZIPResources)
 	assert.False(t, diag.HasError())
 	additionalZIPResources["windows_amd64_binary"] = "windows_amd64_binary"
+	zipFileModesGen, diag := types.MapValueFrom(context.Background(), types.StringType, map[string]string{
+		"windows_amd64_binary": "0755",
+		"LICENSE":              "644",
+	})
+	assert.False(t, diag.HasError())
 
-	initialDataSource := CompileDataSourceModel{
-		Source:             types.StringValue("provider.go"),
-		Destination:        types.StringValue("linux_amd64_binary"),
-		GOOS:               types.StringValue("linux"),
-		GOARCH:             types.StringValue("amd64"),
-		OutputPath:         types.StringValue("linux_amd64_binary"),
-		OutputMD5:          types.StringValue("md5hash"),
-		OutputSHA1:         types.StringValue("sha1hash"),
-		OutputSHA256:       types.StringValue("sha256hash"),
-		OutputSHA512:       types.StringValue("sha512hash"),
-		OutputSHA256Base64: types.StringValue("sha256base64hash"),
-		OutputSHA512Base64: types.StringValue("sha512base64hash"),
-	}
-	firstUpdate := CompileDataSourceModel{
-		Source:             types.StringValue("provider.go"),
-		Destination:        types.StringValue("windows_amd64_binary"),
-		GOOS:               types.StringValue("windows"),
-		GOARCH:             types.StringValue("amd64"),
-		OutputPath:         types.StringValue("windows_amd64_binary"),
-		OutputMD5:          types.StringValue("md5hash"),
-		OutputSHA1:         types.StringValue("sha1hash"),
-		OutputSHA256:       types.StringValue("sha256hash"),
-		OutputSHA512:       types.StringValue("sha512hash"),
-		OutputSHA256Base64: types.StringValue("sha256base64hash"),
-		OutputSHA512Base64: types.StringValue("sha512base64hash"),
-	}
-	secondUpdate := CompileDataSourceModel{
-		Source:             types.StringValue("provider.go"),
-		Destination:        types.StringValue("windows_amd64_binary"),
-		GOOS:               types.StringValue("windows"),
-		GOARCH:             types.StringValue("amd64"),
-		OutputPath:         types.StringValue("windows_amd64_binary"),
-		OutputMD5:          types.StringValue("md5hash"),
-		OutputSHA1:         types.StringValue("sha1hash"),
-		OutputSHA256:       types.StringValue("sha256hash"),
-		OutputSHA512:       types.StringValue("sha512hash"),
-		OutputSHA256Base64: types.StringValue("sha256base64hash"),
-		OutputSHA512Base64: types.StringValue("sha512base64hash"),
-	}
+	initialDataSource := CompileDataSourceModel{BuildModel: BuildModel{
+		Source:               types.StringValue("provider.go"),
+		Destination:          types.StringValue("linux_amd64_binary"),
+		GOOS:                 types.StringValue("linux"),
+		GOARCH:               types.StringValue("amd64"),
+		OutputPath:           types.StringValue("linux_amd64_binary"),
+		OutputMD5:            types.StringValue("md5hash"),
+		OutputSHA1:           types.StringValue("sha1hash"),
+		OutputSHA256:         types.StringValue("sha256hash"),
+		OutputSHA512:         types.StringValue("sha512hash"),
+		OutputSHA256Base64:   types.StringValue("sha256base64hash"),
+		OutputSHA512Base64:   types.StringValue("sha512base64hash"),
+		ArtifactMD5:          types.StringValue("initialartifactmd5hash"),
+		ArtifactSHA1:         types.StringValue("initialartifactsha1hash"),
+		ArtifactSHA256:       types.StringValue("initialartifactsha256hash"),
+		ArtifactSHA512:       types.StringValue("initialartifactsha512hash"),
+		ArtifactSHA256Base64: types.StringValue("initialartifactsha256base64hash"),
+		ArtifactSHA512Base64: types.StringValue("initialartifactsha512base64hash"),
+	}}
+	firstUpdate := CompileDataSourceModel{BuildModel: BuildModel{
+		Source:               types.StringValue("provider.go"),
+		Destination:          types.StringValue("windows_amd64_binary"),
+		GOOS:                 types.StringValue("windows"),
+		GOARCH:               types.StringValue("amd64"),
+		OutputPath:           types.StringValue("windows_amd64_binary"),
+		OutputMD5:            types.StringValue("md5hash"),
+		OutputSHA1:           types.StringValue("sha1hash"),
+		OutputSHA256:         types.StringValue("sha256hash"),
+		OutputSHA512:         types.StringValue("sha512hash"),
+		OutputSHA256Base64:   types.StringValue("sha256base64hash"),
+		OutputSHA512Base64:   types.StringValue("sha512base64hash"),
+		ArtifactMD5:          types.StringValue("firstartifactmd5hash"),
+		ArtifactSHA1:         types.StringValue("firstartifactsha1hash"),
+		ArtifactSHA256:       types.StringValue("firstartifactsha256hash"),
+		ArtifactSHA512:       types.StringValue("firstartifactsha512hash"),
+		ArtifactSHA256Base64: types.StringValue("firstartifactsha256base64hash"),
+		ArtifactSHA512Base64: types.StringValue("firstartifactsha512base64hash"),
+	}}
+	secondUpdate := CompileDataSourceModel{BuildModel: BuildModel{
+		Source:               types.StringValue("provider.go"),
+		Destination:          types.StringValue("windows_amd64_binary"),
+		GOOS:                 types.StringValue("windows"),
+		GOARCH:               types.StringValue("amd64"),
+		OutputPath:           types.StringValue("windows_amd64_binary"),
+		OutputMD5:            types.StringValue("md5hash"),
+		OutputSHA1:           types.StringValue("sha1hash"),
+		OutputSHA256:         types.StringValue("sha256hash"),
+		OutputSHA512:         types.StringValue("sha512hash"),
+		OutputSHA256Base64:   types.StringValue("sha256base64hash"),
+		OutputSHA512Base64:   types.StringValue("sha512base64hash"),
+		ArtifactMD5:          types.StringValue("firstartifactmd5hash"),
+		ArtifactSHA1:         types.StringValue("firstartifactsha1hash"),
+		ArtifactSHA256:       types.StringValue("firstartifactsha256hash"),
+		ArtifactSHA512:       types.StringValue("firstartifactsha512hash"),
+		ArtifactSHA256Base64: types.StringValue("firstartifactsha256base64hash"),
+		ArtifactSHA512Base64: types.StringValue("firstartifactsha512base64hash"),
+	}}
 	thirdUpdate := CompileDataSourceModel{
-		Source:             types.StringValue("provider.go"),
-		Destination:        types.StringValue("windows_amd64_binary"),
-		GOOS:               types.StringValue("windows"),
-		GOARCH:             types.StringValue("amd64"),
-		OutputPath:         types.StringValue("windows_amd64_binary"),
-		OutputMD5:          types.StringValue("md5hash"),
-		OutputSHA1:         types.StringValue("sha1hash"),
-		OutputSHA256:       types.StringValue("sha256hash"),
-		OutputSHA512:       types.StringValue("sha512hash"),
-		OutputSHA256Base64: types.StringValue("sha256base64hash"),
-		OutputSHA512Base64: types.StringValue("sha512base64hash"),
-		ZIP:                types.BoolValue(true),
-		ZIPResources:       additionalZIPResourcesGen,
+		BuildModel: BuildModel{
+			Source:               types.StringValue("provider.go"),
+			Destination:          types.StringValue("windows_amd64_binary"),
+			GOOS:                 types.StringValue("windows"),
+			GOARCH:               types.StringValue("amd64"),
+			OutputPath:           types.StringValue("windows_amd64_binary"),
+			OutputMD5:            types.StringValue("md5hash"),
+			OutputSHA1:           types.StringValue("sha1hash"),
+			OutputSHA256:         types.StringValue("sha256hash"),
+			OutputSHA512:         types.StringValue("sha512hash"),
+			OutputSHA256Base64:   types.StringValue("sha256base64hash"),
+			OutputSHA512Base64:   types.StringValue("sha512base64hash"),
+			ArtifactMD5:          types.StringValue("thirdartifactmd5hash"),
+			ArtifactSHA1:         types.StringValue("thirdartifactsha1hash"),
+			ArtifactSHA256:       types.StringValue("thirdartifactsha256hash"),
+			ArtifactSHA512:       types.StringValue("thirdartifactsha512hash"),
+			ArtifactSHA256Base64: types.StringValue("thirdartifactsha256base64hash"),
+			ArtifactSHA512Base64: types.StringValue("thirdartifactsha512base64hash"),
+			ZIPResources:         additionalZIPResourcesGen,
+			ZIPFileModes:         zipFileModesGen,
+		},
+		ZIP: types.BoolValue(true),
 	}
 
-	mockHasher.On("ReadFile", initialDataSource.OutputPath.ValueString()).Times(3).Return([]byte("123"), nil)
-	mockHasher.On("CombinedHash", []byte("123")).Times(3).Return(hasher.CombinedHash{
-		MD5:          initialDataSource.OutputMD5.ValueString(),
-		SHA1:         initialDataSource.OutputSHA1.ValueString(),
-		SHA256:       initialDataSource.OutputSHA256.ValueString(),
-		SHA512:       initialDataSource.OutputSHA512.ValueString(),
-		SHA256Base64: initialDataSource.OutputSHA256Base64.ValueString(),
-		SHA512Base64: initialDataSource.OutputSHA512Base64.ValueString(),
-	}, nil)
+	ldflagsVariables, diag := types.MapValueFrom(context.Background(), types.StringType, map[string]string{
+		"main.version": "v1.0.0",
+	})
+	assert.False(t, diag.HasError())
+	fourthUpdate := CompileDataSourceModel{BuildModel: BuildModel{
+		Source:               types.StringValue("provider.go"),
+		Destination:          types.StringValue("linux_arm64_binary"),
+		GOOS:                 types.StringValue("linux"),
+		GOARCH:               types.StringValue("arm64"),
+		OutputPath:           types.StringValue("linux_arm64_binary"),
+		OutputMD5:            types.StringValue("md5hash"),
+		OutputSHA1:           types.StringValue("sha1hash"),
+		OutputSHA256:         types.StringValue("sha256hash"),
+		OutputSHA512:         types.StringValue("sha512hash"),
+		OutputSHA256Base64:   types.StringValue("sha256base64hash"),
+		OutputSHA512Base64:   types.StringValue("sha512base64hash"),
+		ArtifactMD5:          types.StringValue("fourthartifactmd5hash"),
+		ArtifactSHA1:         types.StringValue("fourthartifactsha1hash"),
+		ArtifactSHA256:       types.StringValue("fourthartifactsha256hash"),
+		ArtifactSHA512:       types.StringValue("fourthartifactsha512hash"),
+		ArtifactSHA256Base64: types.StringValue("fourthartifactsha256base64hash"),
+		ArtifactSHA512Base64: types.StringValue("fourthartifactsha512base64hash"),
+		LDFlags: &LDFlagsModel{
+			Variables:    ldflagsVariables,
+			StripSymbols: types.BoolValue(true),
+			StripDWARF:   types.BoolValue(true),
+		},
+	}}
+
+	tags, diag := types.ListValueFrom(context.Background(), types.StringType, []string{"netgo", "lambda.norpc"})
+	assert.False(t, diag.HasError())
+	fifthUpdate := CompileDataSourceModel{BuildModel: BuildModel{
+		Source:               types.StringValue("provider.go"),
+		Destination:          types.StringValue("linux_arm64_binary"),
+		GOOS:                 types.StringValue("linux"),
+		GOARCH:               types.StringValue("arm64"),
+		OutputPath:           types.StringValue("linux_arm64_binary"),
+		OutputMD5:            types.StringValue("taggedmd5hash"),
+		OutputSHA1:           types.StringValue("taggedsha1hash"),
+		OutputSHA256:         types.StringValue("taggedsha256hash"),
+		OutputSHA512:         types.StringValue("taggedsha512hash"),
+		OutputSHA256Base64:   types.StringValue("taggedsha256base64hash"),
+		OutputSHA512Base64:   types.StringValue("taggedsha512base64hash"),
+		ArtifactMD5:          types.StringValue("fourthartifactmd5hash"),
+		ArtifactSHA1:         types.StringValue("fourthartifactsha1hash"),
+		ArtifactSHA256:       types.StringValue("fourthartifactsha256hash"),
+		ArtifactSHA512:       types.StringValue("fourthartifactsha512hash"),
+		ArtifactSHA256Base64: types.StringValue("fourthartifactsha256base64hash"),
+		ArtifactSHA512Base64: types.StringValue("fourthartifactsha512base64hash"),
+		Tags:                 tags,
+	}}
+
+	env, diag := types.MapValueFrom(context.Background(), types.StringType, map[string]string{"GOARM": "7"})
+	assert.False(t, diag.HasError())
+	sixthUpdate := CompileDataSourceModel{BuildModel: BuildModel{
+		Source:               types.StringValue("provider.go"),
+		Destination:          types.StringValue("linux_arm_binary"),
+		GOOS:                 types.StringValue("linux"),
+		GOARCH:               types.StringValue("arm"),
+		OutputPath:           types.StringValue("linux_arm_binary"),
+		OutputMD5:            types.StringValue("envmd5hash"),
+		OutputSHA1:           types.StringValue("envsha1hash"),
+		OutputSHA256:         types.StringValue("envsha256hash"),
+		OutputSHA512:         types.StringValue("envsha512hash"),
+		OutputSHA256Base64:   types.StringValue("envsha256base64hash"),
+		OutputSHA512Base64:   types.StringValue("envsha512base64hash"),
+		ArtifactMD5:          types.StringValue("sixthartifactmd5hash"),
+		ArtifactSHA1:         types.StringValue("sixthartifactsha1hash"),
+		ArtifactSHA256:       types.StringValue("sixthartifactsha256hash"),
+		ArtifactSHA512:       types.StringValue("sixthartifactsha512hash"),
+		ArtifactSHA256Base64: types.StringValue("sixthartifactsha256base64hash"),
+		ArtifactSHA512Base64: types.StringValue("sixthartifactsha512base64hash"),
+		CGOEnabled:           types.BoolValue(false),
+		Env:                  env,
+		Reproducible:         types.BoolValue(true),
+	}}
+
+	seventhUpdate := CompileDataSourceModel{BuildModel: BuildModel{
+		Source:               types.StringValue("provider.go"),
+		Destination:          types.StringValue("linux_amd64_binary"),
+		GOOS:                 types.StringValue("linux"),
+		GOARCH:               types.StringValue("amd64"),
+		OutputPath:           types.StringValue("linux_amd64_binary"),
+		OutputMD5:            types.StringValue("md5hash"),
+		OutputSHA1:           types.StringValue("sha1hash"),
+		OutputSHA256:         types.StringValue("sha256hash"),
+		OutputSHA512:         types.StringValue("sha512hash"),
+		OutputSHA256Base64:   types.StringValue("sha256base64hash"),
+		OutputSHA512Base64:   types.StringValue("sha512base64hash"),
+		ArtifactMD5:          types.StringValue("seventhartifactmd5hash"),
+		ArtifactSHA1:         types.StringValue("seventhartifactsha1hash"),
+		ArtifactSHA256:       types.StringValue("seventhartifactsha256hash"),
+		ArtifactSHA512:       types.StringValue("seventhartifactsha512hash"),
+		ArtifactSHA256Base64: types.StringValue("seventhartifactsha256base64hash"),
+		ArtifactSHA512Base64: types.StringValue("seventhartifactsha512base64hash"),
+		ArchiveFormat:        types.StringValue("tar.gz"),
+		ZIPExcludes:          types.ListValueMust(types.StringType, []attr.Value{types.StringValue("**/.DS_Store")}),
+	}}
+
+	eighthUpdate := CompileDataSourceModel{BuildModel: BuildModel{
+		Source:      types.StringValue("provider.go"),
+		Destination: types.StringValue("linux_amd64_checksummed_binary"),
+		GOOS:        types.StringValue("linux"),
+		GOARCH:      types.StringValue("amd64"),
+		OutputPath:  types.StringValue("linux_amd64_checksummed_binary"),
+		HashAlgorithms: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue(hasher.AlgorithmBLAKE2b256),
+			types.StringValue(hasher.AlgorithmCRC32C),
+		}),
+	}}
+
+	mockHasher.On("HashFile", initialDataSource.OutputPath.ValueString(), []string(nil)).Times(3).Return(artifactHashes(initialDataSource), nil)
 
 	basePath := filepath.Dir(initialDataSource.Source.ValueString())
-	mockHasher.On("HashDir", basePath).Return(&hasher.CombinedHash{
-		MD5:          initialDataSource.OutputMD5.ValueString(),
-		SHA1:         initialDataSource.OutputSHA1.ValueString(),
-		SHA256:       initialDataSource.OutputSHA256.ValueString(),
-		SHA512:       initialDataSource.OutputSHA512.ValueString(),
-		SHA256Base64: initialDataSource.OutputSHA256Base64.ValueString(),
-		SHA512Base64: initialDataSource.OutputSHA512Base64.ValueString(),
-	}, nil)
-	mockCompiler.On("Compile",
+	mockHasher.On("HashDir", basePath, mock.Anything).Return(sourceHashes(initialDataSource), nil)
+	mockCompiler.On("Compile", mock.Anything,
 		*compiler.NewConfig().
 			Source(initialDataSource.Source.ValueString()).
 			Destination(initialDataSource.Destination.ValueString()).
@@ -127,16 +416,8 @@ func TestAccCompileDataSource(t *testing.T) {
 	).Times(3).
 		Return(initialDataSource.OutputPath.ValueString(), nil)
 
-	mockHasher.On("ReadFile", firstUpdate.OutputPath.ValueString()).Times(6).Return([]byte("333"), nil)
-	mockHasher.On("CombinedHash", []byte("333")).Times(6).Return(hasher.CombinedHash{
-		MD5:          firstUpdate.OutputMD5.ValueString(),
-		SHA1:         firstUpdate.OutputSHA1.ValueString(),
-		SHA256:       firstUpdate.OutputSHA256.ValueString(),
-		SHA512:       firstUpdate.OutputSHA512.ValueString(),
-		SHA256Base64: firstUpdate.OutputSHA256Base64.ValueString(),
-		SHA512Base64: firstUpdate.OutputSHA512Base64.ValueString(),
-	}, nil)
-	mockCompiler.On("Compile",
+	mockHasher.On("HashFile", firstUpdate.OutputPath.ValueString(), []string(nil)).Times(6).Return(artifactHashes(firstUpdate), nil)
+	mockCompiler.On("Compile", mock.Anything,
 		*compiler.NewConfig().
 			Source(firstUpdate.Source.ValueString()).
 			Destination(firstUpdate.Destination.ValueString()).
@@ -145,8 +426,8 @@ func TestAccCompileDataSource(t *testing.T) {
 	).Times(3).
 		Return(firstUpdate.OutputPath.ValueString(), nil)
 
-	// ReadFile and CombinedHash are reused
-	mockCompiler.On("Compile",
+	// HashFile is reused
+	mockCompiler.On("Compile", mock.Anything,
 		*compiler.NewConfig().
 			Source(secondUpdate.Source.ValueString()).
 			Destination(secondUpdate.Destination.ValueString()).
@@ -155,17 +436,12 @@ func TestAccCompileDataSource(t *testing.T) {
 	).Times(3).
 		Return(secondUpdate.OutputPath.ValueString(), nil)
 
-	mockPackager.On("Zip", thirdUpdate.OutputPath.ValueString()+".zip", additionalZIPResources).Times(3).Return(nil)
-	mockHasher.On("ReadFile", thirdUpdate.OutputPath.ValueString()+".zip").Times(3).Return([]byte("666"), nil)
-	mockHasher.On("CombinedHash", []byte("666")).Times(3).Return(hasher.CombinedHash{
-		MD5:          firstUpdate.OutputMD5.ValueString(),
-		SHA1:         firstUpdate.OutputSHA1.ValueString(),
-		SHA256:       firstUpdate.OutputSHA256.ValueString(),
-		SHA512:       firstUpdate.OutputSHA512.ValueString(),
-		SHA256Base64: firstUpdate.OutputSHA256Base64.ValueString(),
-		SHA512Base64: firstUpdate.OutputSHA512Base64.ValueString(),
-	}, nil)
-	mockCompiler.On("Compile",
+	mockZIPPackager.On("Package", thirdUpdate.OutputPath.ValueString()+".zip", additionalZIPResources, map[string]os.FileMode{
+		"windows_amd64_binary": 0755,
+		"LICENSE":              0644,
+	}, []string(nil)).Times(3).Return(nil)
+	mockHasher.On("HashFile", thirdUpdate.OutputPath.ValueString()+".zip", []string(nil)).Times(3).Return(artifactHashes(thirdUpdate), nil)
+	mockCompiler.On("Compile", mock.Anything,
 		*compiler.NewConfig().
 			Source(thirdUpdate.Source.ValueString()).
 			Destination(thirdUpdate.Destination.ValueString()).
@@ -174,9 +450,88 @@ func TestAccCompileDataSource(t *testing.T) {
 	).Times(3).
 		Return(thirdUpdate.OutputPath.ValueString(), nil)
 
+	mockCompiler.On("Compile", mock.Anything,
+		*compiler.NewConfig().
+			Source(fourthUpdate.Source.ValueString()).
+			Destination(fourthUpdate.Destination.ValueString()).
+			GOOS(fourthUpdate.GOOS.ValueString()).
+			GOARCH(fourthUpdate.GOARCH.ValueString()).
+			LDFlags(compiler.LDFlags{
+				Variables:    map[string]string{"main.version": "v1.0.0"},
+				StripSymbols: true,
+				StripDWARF:   true,
+			}),
+	).Times(3).
+		Return(fourthUpdate.OutputPath.ValueString(), nil)
+	mockHasher.On("CombinedHash", []byte(sourceHashes(initialDataSource).Digests()+"\nldflags=-s -w -X main.version=v1.0.0"), []string(nil)).Times(3).Return(sourceHashes(fourthUpdate), nil)
+
+	mockCompiler.On("Compile", mock.Anything,
+		*compiler.NewConfig().
+			Source(fifthUpdate.Source.ValueString()).
+			Destination(fifthUpdate.Destination.ValueString()).
+			GOOS(fifthUpdate.GOOS.ValueString()).
+			GOARCH(fifthUpdate.GOARCH.ValueString()).
+			Tags([]string{"netgo", "lambda.norpc"}),
+	).Times(3).
+		Return(fifthUpdate.OutputPath.ValueString(), nil)
+	mockHasher.On("CombinedHash", []byte(sourceHashes(initialDataSource).Digests()+"\ntags=lambda.norpc,netgo"), []string(nil)).Times(3).Return(sourceHashes(fifthUpdate), nil)
+
+	mockCompiler.On("Compile", mock.Anything,
+		*compiler.NewConfig().
+			Source(sixthUpdate.Source.ValueString()).
+			Destination(sixthUpdate.Destination.ValueString()).
+			GOOS(sixthUpdate.GOOS.ValueString()).
+			GOARCH(sixthUpdate.GOARCH.ValueString()).
+			CGOEnabled(false).
+			Env(map[string]string{"GOARM": "7"}).
+			Reproducible(true),
+	).Times(3).
+		Return(sixthUpdate.OutputPath.ValueString(), nil)
+	mockHasher.On("CombinedHash", []byte(sourceHashes(initialDataSource).Digests()+"\ncgo=false\nenv=GOARM=7\nreproducible=true"), []string(nil)).Times(3).Return(sourceHashes(sixthUpdate), nil)
+
+	mockCompiler.On("Compile", mock.Anything,
+		*compiler.NewConfig().
+			Source(seventhUpdate.Source.ValueString()).
+			Destination(seventhUpdate.Destination.ValueString()).
+			GOOS(seventhUpdate.GOOS.ValueString()).
+			GOARCH(seventhUpdate.GOARCH.ValueString()),
+	).Times(3).
+		Return(seventhUpdate.OutputPath.ValueString(), nil)
+	mockHasher.On("HashFile", fourthUpdate.OutputPath.ValueString(), []string(nil)).Times(6).Return(artifactHashes(fourthUpdate), nil)
+	mockHasher.On("HashFile", sixthUpdate.OutputPath.ValueString(), []string(nil)).Times(3).Return(artifactHashes(sixthUpdate), nil)
+	mockHasher.On("HashFile", seventhUpdate.OutputPath.ValueString()+".tar.gz", []string(nil)).Times(3).Return(artifactHashes(seventhUpdate), nil)
+	mockTarGzPackager.On("Package", seventhUpdate.OutputPath.ValueString()+".tar.gz", map[string]string{
+		seventhUpdate.OutputPath.ValueString(): seventhUpdate.OutputPath.ValueString(),
+	}, map[string]os.FileMode(nil), []string{"**/.DS_Store"}).Times(3).Return(nil)
+
+	mockCompiler.On("Compile", mock.Anything,
+		*compiler.NewConfig().
+			Source(eighthUpdate.Source.ValueString()).
+			Destination(eighthUpdate.Destination.ValueString()).
+			GOOS(eighthUpdate.GOOS.ValueString()).
+			GOARCH(eighthUpdate.GOARCH.ValueString()),
+	).Times(3).
+		Return(eighthUpdate.OutputPath.ValueString(), nil)
+	mockHasher.On("HashFile", eighthUpdate.OutputPath.ValueString(), []string{hasher.AlgorithmBLAKE2b256, hasher.AlgorithmCRC32C}).Times(3).Return(&hasher.CombinedHash{
+		Hashes: map[string]string{
+			"blake2b_256":        "eighthartifactblake2bhash",
+			"blake2b_256_base64": "eighthartifactblake2bbase64hash",
+			"crc32c":             "eighthartifactcrc32chash",
+			"crc32c_base64":      "eighthartifactcrc32cbase64hash",
+		},
+	}, nil)
+
+	invalidGOARCH := initialDataSource
+	invalidGOARCH.GOARCH = types.StringValue("amd46")
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Invalid GOARCH testing
+			{
+				Config:      compilerDataSourceFromModel(t, invalidGOARCH),
+				ExpectError: regexp.MustCompile(`Did you\s+mean 'amd64'`),
+			},
 			// Read testing
 			{
 				Config: compilerDataSourceFromModel(t, initialDataSource),
@@ -186,6 +541,8 @@ func TestAccCompileDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "goos", initialDataSource.GOOS.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "goarch", initialDataSource.GOARCH.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_path", initialDataSource.OutputPath.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256", initialDataSource.ArtifactSHA256.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256_base64", initialDataSource.ArtifactSHA256Base64.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_md5", initialDataSource.OutputMD5.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha1", initialDataSource.OutputSHA1.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha256", initialDataSource.OutputSHA256.ValueString()),
@@ -203,6 +560,8 @@ func TestAccCompileDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "goos", firstUpdate.GOOS.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "goarch", firstUpdate.GOARCH.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_path", firstUpdate.OutputPath.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256", firstUpdate.ArtifactSHA256.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256_base64", firstUpdate.ArtifactSHA256Base64.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_md5", firstUpdate.OutputMD5.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha1", firstUpdate.OutputSHA1.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha256", firstUpdate.OutputSHA256.ValueString()),
@@ -220,6 +579,8 @@ func TestAccCompileDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "goos", secondUpdate.GOOS.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "goarch", secondUpdate.GOARCH.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_path", secondUpdate.OutputPath.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256", secondUpdate.ArtifactSHA256.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256_base64", secondUpdate.ArtifactSHA256Base64.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_md5", secondUpdate.OutputMD5.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha1", secondUpdate.OutputSHA1.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha256", secondUpdate.OutputSHA256.ValueString()),
@@ -237,6 +598,8 @@ func TestAccCompileDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "goos", thirdUpdate.GOOS.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "goarch", thirdUpdate.GOARCH.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_path", thirdUpdate.OutputPath.ValueString()+".zip"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256", thirdUpdate.ArtifactSHA256.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256_base64", thirdUpdate.ArtifactSHA256Base64.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_md5", thirdUpdate.OutputMD5.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha1", thirdUpdate.OutputSHA1.ValueString()),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha256", thirdUpdate.OutputSHA256.ValueString()),
@@ -246,22 +609,126 @@ func TestAccCompileDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "zip", "true"),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "zip_resources.../../LICENSE", "LICENSE"),
 					resource.TestCheckResourceAttr("data.gopackager_compile.test", "zip_resources.../provider", "a/provider"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "zip_file_modes.windows_amd64_binary", "0755"),
+				),
+			},
+			// Fourth update testing
+			{
+				Config: compilerDataSourceFromModel(t, fourthUpdate),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "goarch", fourthUpdate.GOARCH.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_path", fourthUpdate.OutputPath.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256", fourthUpdate.ArtifactSHA256.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256_base64", fourthUpdate.ArtifactSHA256Base64.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "ldflags.variables.main.version", "v1.0.0"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "ldflags.strip_symbols", "true"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "ldflags.strip_dwarf", "true"),
+				),
+			},
+			// Fifth update testing
+			{
+				Config: compilerDataSourceFromModel(t, fifthUpdate),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "tags.0", "netgo"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "tags.1", "lambda.norpc"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_md5", fifthUpdate.OutputMD5.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha1", fifthUpdate.OutputSHA1.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha256", fifthUpdate.OutputSHA256.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha512", fifthUpdate.OutputSHA512.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha256_base64", fifthUpdate.OutputSHA256Base64.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha512_base64", fifthUpdate.OutputSHA512Base64.ValueString()),
+				),
+			},
+			// Sixth update testing
+			{
+				Config: compilerDataSourceFromModel(t, sixthUpdate),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "cgo_enabled", "false"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "env.GOARM", "7"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "reproducible", "true"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_path", sixthUpdate.OutputPath.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256", sixthUpdate.ArtifactSHA256.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256_base64", sixthUpdate.ArtifactSHA256Base64.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha256", sixthUpdate.OutputSHA256.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha256_base64", sixthUpdate.OutputSHA256Base64.ValueString()),
+				),
+			},
+			// Seventh update testing
+			{
+				Config: compilerDataSourceFromModel(t, seventhUpdate),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "archive_format", "tar.gz"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "zip_excludes.0", "**/.DS_Store"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_path", seventhUpdate.OutputPath.ValueString()+".tar.gz"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256", seventhUpdate.ArtifactSHA256.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_sha256_base64", seventhUpdate.ArtifactSHA256Base64.ValueString()),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_sha256", seventhUpdate.OutputSHA256.ValueString()),
+				),
+			},
+			// Eighth update testing
+			{
+				Config: compilerDataSourceFromModel(t, eighthUpdate),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "hash_algorithms.#", "2"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_hashes.%", "4"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_hashes.crc32c", "eighthartifactcrc32chash"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "artifact_hashes.blake2b_256_base64", "eighthartifactblake2bbase64hash"),
+					resource.TestCheckNoResourceAttr("data.gopackager_compile.test", "artifact_sha256"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_hashes.sha256", initialDataSource.OutputSHA256.ValueString()),
 				),
 			},
 		},
 	})
 }
 
+// artifactHashes returns the artifact hashes of the model as combined hash.
+func artifactHashes(model CompileDataSourceModel) *hasher.CombinedHash {
+	return combinedHash(model.ArtifactMD5, model.ArtifactSHA1, model.ArtifactSHA256, model.ArtifactSHA512, model.ArtifactSHA256Base64, model.ArtifactSHA512Base64)
+}
+
+// sourceHashes returns the source hashes of the model as combined hash.
+func sourceHashes(model CompileDataSourceModel) *hasher.CombinedHash {
+	return combinedHash(model.OutputMD5, model.OutputSHA1, model.OutputSHA256, model.OutputSHA512, model.OutputSHA256Base64, model.OutputSHA512Base64)
+}
+
+// combinedHash returns the combined hash of the default algorithms.
+func combinedHash(md5, sha1, sha256, sha512, sha256Base64, sha512Base64 types.String) *hasher.CombinedHash {
+	return &hasher.CombinedHash{
+		MD5:          md5.ValueString(),
+		SHA1:         sha1.ValueString(),
+		SHA256:       sha256.ValueString(),
+		SHA512:       sha512.ValueString(),
+		SHA256Base64: sha256Base64.ValueString(),
+		SHA512Base64: sha512Base64.ValueString(),
+		Hashes: map[string]string{
+			hasher.AlgorithmMD5:                          md5.ValueString(),
+			hasher.AlgorithmSHA1:                         sha1.ValueString(),
+			hasher.AlgorithmSHA256:                       sha256.ValueString(),
+			hasher.AlgorithmSHA512:                       sha512.ValueString(),
+			hasher.AlgorithmSHA256 + hasher.Base64Suffix: sha256Base64.ValueString(),
+			hasher.AlgorithmSHA512 + hasher.Base64Suffix: sha512Base64.ValueString(),
+		},
+	}
+}
+
 func compilerDataSourceFromModel(t *testing.T, model CompileDataSourceModel) string {
 	t.Helper()
 
 	zip := ""
 	zipResource := ""
+	ldflags := ""
+	tags := ""
+	build := ""
 
 	if !model.ZIP.IsNull() && !model.ZIP.IsUnknown() && model.ZIP.ValueBool() {
 		zip = `zip = true`
 	}
 
+	if !model.ArchiveFormat.IsNull() && !model.ArchiveFormat.IsUnknown() {
+		zip = "archive_format = " + model.ArchiveFormat.String()
+	}
+
 	if !model.ZIPResources.IsNull() && !model.ZIPResources.IsUnknown() {
 		additionalFiles := map[string]string{}
 		zipResource += "zip_resources = {\n"
@@ -275,6 +742,69 @@ func compilerDataSourceFromModel(t *testing.T, model CompileDataSourceModel) str
 		zipResource += "	}"
 	}
 
+	if !model.ZIPFileModes.IsNull() && !model.ZIPFileModes.IsUnknown() {
+		fileModes := map[string]string{}
+		zipResource += "\n	zip_file_modes = {\n"
+
+		diag := model.ZIPFileModes.ElementsAs(context.Background(), &fileModes, false)
+		assert.False(t, diag.HasError())
+		for k, v := range fileModes {
+			zipResource += fmt.Sprintf("		\"%s\" = \"%s\"\n", k, v)
+		}
+
+		zipResource += "	}"
+	}
+
+	if !model.ZIPExcludes.IsNull() && !model.ZIPExcludes.IsUnknown() {
+		zipResource += "\n	zip_excludes = " + model.ZIPExcludes.String()
+	}
+
+	if !model.Tags.IsNull() && !model.Tags.IsUnknown() {
+		tags = "tags = " + model.Tags.String()
+	}
+
+	if !model.HashAlgorithms.IsNull() && !model.HashAlgorithms.IsUnknown() {
+		build += "hash_algorithms = " + model.HashAlgorithms.String() + "\n"
+	}
+
+	if !model.CGOEnabled.IsNull() && !model.CGOEnabled.IsUnknown() {
+		build += fmt.Sprintf("cgo_enabled = %t\n", model.CGOEnabled.ValueBool())
+	}
+
+	if !model.Env.IsNull() && !model.Env.IsUnknown() {
+		env := map[string]string{}
+		diag := model.Env.ElementsAs(context.Background(), &env, false)
+		assert.False(t, diag.HasError())
+
+		build += "	env = {\n"
+		for k, v := range env {
+			build += fmt.Sprintf("		\"%s\" = \"%s\"\n", k, v)
+		}
+
+		build += "	}\n"
+	}
+
+	if !model.Reproducible.IsNull() && !model.Reproducible.IsUnknown() {
+		build += fmt.Sprintf("	reproducible = %t\n", model.Reproducible.ValueBool())
+	}
+
+	if model.LDFlags != nil {
+		variables := map[string]string{}
+		ldflags += "ldflags {\n"
+
+		diag := model.LDFlags.Variables.ElementsAs(context.Background(), &variables, false)
+		assert.False(t, diag.HasError())
+		ldflags += "		variables = {\n"
+		for k, v := range variables {
+			ldflags += fmt.Sprintf("			\"%s\" = \"%s\"\n", k, v)
+		}
+
+		ldflags += "		}\n"
+		ldflags += fmt.Sprintf("		strip_symbols = %t\n", model.LDFlags.StripSymbols.ValueBool())
+		ldflags += fmt.Sprintf("		strip_dwarf = %t\n", model.LDFlags.StripDWARF.ValueBool())
+		ldflags += "	}"
+	}
+
 	return fmt.Sprintf(`
 data "gopackager_compile" "test" {
 	source = %s
@@ -283,6 +813,313 @@ data "gopackager_compile" "test" {
 	goarch = %s
 	%s
 	%s
+	%s
+	%s
+	%s
+}
+	`, model.Source.String(), model.Destination.String(), model.GOOS.String(), model.GOARCH.String(), zip, zipResource, tags, build, ldflags)
+}
+
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccCompileDataSourceTargets(t *testing.T) {
+	compilerBackup, packagersBackup, hasherBackup := globalCompiler, globalPackagers, globalHasher
+	t.Cleanup(func() {
+		globalCompiler, globalPackagers, globalHasher = compilerBackup, packagersBackup, hasherBackup
+	})
+
+	sourceDir := t.TempDir()
+	distDir := t.TempDir()
+	source := filepath.Join(sourceDir, "main.go")
+	assert.NoError(t, os.WriteFile(source, []byte("package main\n\nfunc main() {}\n"), 0o600))
+
+	// The mock compiler writes the target as binary and tracks the number of parallel builds.
+	var active, maxActive atomic.Int32
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Compile", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		current := active.Add(1)
+		defer active.Add(-1)
+
+		for previous := maxActive.Load(); current > previous && !maxActive.CompareAndSwap(previous, current); {
+			previous = maxActive.Load()
+		}
+
+		conf := args.Get(1).(compiler.Config) //nolint:forcetypeassert
+		assert.NoError(t, os.MkdirAll(filepath.Dir(conf.GetDestination()), 0o700))
+		assert.NoError(t, os.WriteFile(conf.GetDestination(), []byte(conf.GetGOOS()+conf.GetGOARCH()+conf.GetVariant()), 0o600))
+		time.Sleep(50 * time.Millisecond)
+	}).Return(func(conf compiler.Config) string {
+		return conf.GetDestination()
+	}, nil)
+	mockCompiler.On("Ports", mock.Anything, "").Return(testPorts, nil)
+	globalCompiler = &mockCompiler
+	globalPackagers = packager.Packagers()
+	globalHasher = hasher.New()
+
+	testAccProtoV6ProviderFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"gopackager": providerserver.NewProtocol6WithError(New("test")()),
+	}
+
+	targetsConfig := func(targets string) string {
+		return fmt.Sprintf(`
+data "gopackager_compile" "test" {
+	source = %q
+	destination = %q
+	archive_format = "zip"
+	concurrency = 2
+	targets = [%s]
+}
+		`, source, filepath.Join(distDir, "cli"), targets)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: targetsConfig(`
+		{ goos = "linux", goarch = "amd64" },
+		{ goos = "linux", goarch = "amd64" },
+				`),
+				ExpectError: regexp.MustCompile("Duplicate target"),
+			},
+			{
+				Config: targetsConfig(`
+		{ goos = "linux", goarch = "s390x", variant = "v1" },
+				`),
+				ExpectError: regexp.MustCompile("variant not supported"),
+			},
+			{
+				Config: targetsConfig(`
+		{ goos = "linx", goarch = "amd64" },
+				`),
+				ExpectError: regexp.MustCompile(`Did you\s+mean 'linux'`),
+			},
+			{
+				Config: targetsConfig(`
+		{ goos = "darwin", goarch = "amd64" },
+				`),
+				ExpectError: regexp.MustCompile(`for GOOS 'darwin'. Did you\s+mean 'arm64'`),
+			},
+			{
+				Config: targetsConfig(`
+		{ goos = "linux", goarch = "amd64" },
+		{ goos = "linux", goarch = "arm", variant = "7" },
+		{ goos = "windows", goarch = "amd64" },
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "target_outputs.%", "3"),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "target_outputs.linux_amd64.output_path", filepath.Join(distDir, "linux_amd64", "cli.zip")),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "target_outputs.linux_arm_7.output_path", filepath.Join(distDir, "linux_arm_7", "cli.zip")),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "target_outputs.windows_amd64.output_path", filepath.Join(distDir, "windows_amd64", "cli.zip")),
+					resource.TestCheckResourceAttrSet("data.gopackager_compile.test", "target_outputs.linux_arm_7.artifact_sha256"),
+					resource.TestCheckResourceAttrSet("data.gopackager_compile.test", "target_outputs.linux_arm_7.artifact_sha256_base64"),
+					resource.TestCheckResourceAttrSet("data.gopackager_compile.test", "output_sha256"),
+					resource.TestCheckNoResourceAttr("data.gopackager_compile.test", "output_path"),
+					func(*terraform.State) error {
+						if maxActive.Load() != 2 {
+							return fmt.Errorf("expected 2 parallel builds, but got %d", maxActive.Load())
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
 }
-	`, model.Source.String(), model.Destination.String(), model.GOOS.String(), model.GOARCH.String(), zip, zipResource)
+
+// Read is tested by calling the data source directly, so it doesn't require Terraform like `TestAccCompileDataSourceTargets`.
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccCompileDataSourceRead(t *testing.T) {
+	compilerBackup, packagersBackup, hasherBackup := globalCompiler, globalPackagers, globalHasher
+	t.Cleanup(func() {
+		globalCompiler, globalPackagers, globalHasher = compilerBackup, packagersBackup, hasherBackup
+	})
+
+	sourceDir := t.TempDir()
+	source := filepath.Join(sourceDir, "main.go")
+	assert.NoError(t, os.WriteFile(source, []byte("package main\n\nfunc main() {}\n"), 0o600))
+
+	// The mock compiler writes the platform as binary, so that the artifacts differ per target.
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Compile", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		conf := args.Get(1).(compiler.Config) //nolint:forcetypeassert
+		assert.NoError(t, os.MkdirAll(filepath.Dir(conf.GetDestination()), 0o700))
+		assert.NoError(t, os.WriteFile(conf.GetDestination(), []byte(conf.GetGOOS()+conf.GetGOARCH()), 0o600))
+	}).Return(func(conf compiler.Config) string {
+		return conf.GetDestination()
+	}, nil)
+	mockCompiler.On("Ports", mock.Anything, mock.Anything).Return(testPorts, nil)
+	globalCompiler = &mockCompiler
+	globalPackagers = packager.Packagers()
+	globalHasher = hasher.New()
+
+	dataSource := &CompileDataSource{defaults: &ProviderDefaults{}}
+
+	read := func(t *testing.T, values map[string]tftypes.Value) CompileDataSourceModel {
+		t.Helper()
+
+		config := testDataSourceConfig(t, dataSource, values)
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: tftypes.NewValue(config.Raw.Type(), nil)}}
+		dataSource.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.True(t, resp.State.Raw.IsFullyKnown())
+
+		var model CompileDataSourceModel
+		assert.False(t, resp.State.Get(context.Background(), &model).HasError())
+
+		return model
+	}
+
+	t.Run("Single", func(t *testing.T) {
+		destination := filepath.Join(t.TempDir(), "cli")
+		model := read(t, map[string]tftypes.Value{
+			"source":         tftypes.NewValue(tftypes.String, source),
+			"destination":    tftypes.NewValue(tftypes.String, destination),
+			"goos":           tftypes.NewValue(tftypes.String, "linux"),
+			"goarch":         tftypes.NewValue(tftypes.String, "amd64"),
+			"archive_format": tftypes.NewValue(tftypes.String, "zip"),
+		})
+
+		assert.Equal(t, destination+".zip", model.OutputPath.ValueString())
+		assert.FileExists(t, destination+".zip")
+		assert.NotEmpty(t, model.OutputSHA256.ValueString())
+		assert.NotEmpty(t, model.ArtifactSHA256.ValueString())
+		assert.Empty(t, model.TargetOutputs)
+	})
+
+	t.Run("Targets", func(t *testing.T) {
+		distDir := t.TempDir()
+		targetType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"goos": tftypes.String, "goarch": tftypes.String, "variant": tftypes.String}}
+		target := func(goos, goarch string) tftypes.Value {
+			return tftypes.NewValue(targetType, map[string]tftypes.Value{
+				"goos":    tftypes.NewValue(tftypes.String, goos),
+				"goarch":  tftypes.NewValue(tftypes.String, goarch),
+				"variant": tftypes.NewValue(tftypes.String, nil),
+			})
+		}
+
+		model := read(t, map[string]tftypes.Value{
+			"source":      tftypes.NewValue(tftypes.String, source),
+			"destination": tftypes.NewValue(tftypes.String, filepath.Join(distDir, "cli")),
+			"targets":     tftypes.NewValue(tftypes.List{ElementType: targetType}, []tftypes.Value{target("linux", "amd64"), target("windows", "amd64")}),
+		})
+
+		// The top level outputs only describe the source.
+		assert.True(t, model.OutputPath.IsNull())
+		assert.NotEmpty(t, model.OutputSHA256.ValueString())
+		assert.Len(t, model.TargetOutputs, 2)
+		for _, key := range []string{"linux_amd64", "windows_amd64"} {
+			output := filepath.Join(distDir, key, "cli")
+			assert.Equal(t, output, model.TargetOutputs[key].OutputPath.ValueString())
+			assert.FileExists(t, output)
+		}
+		assert.NotEqual(t, model.TargetOutputs["linux_amd64"].ArtifactSHA256, model.TargetOutputs["windows_amd64"].ArtifactSHA256)
+	})
+}
+
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccCompileDataSourceModelBuildTargetsInvalid(t *testing.T) {
+	compilerBackup, hasherBackup := globalCompiler, globalHasher
+	t.Cleanup(func() {
+		globalCompiler, globalHasher = compilerBackup, hasherBackup
+	})
+
+	mockCompiler := compiler.MockCompiler{}
+	mockHasher := hasher.MockHasher{}
+	globalCompiler = &mockCompiler
+	globalHasher = &mockHasher
+
+	target := func(goos, goarch, variant string) TargetModel {
+		return TargetModel{GOOS: types.StringValue(goos), GOARCH: types.StringValue(goarch), Variant: types.StringValue(variant)}
+	}
+
+	for name, targets := range map[string][]TargetModel{
+		"Duplicate_Target": {target("linux", "arm64", ""), target("linux", "amd64", ""), target("linux", "amd64", "")},
+		"Invalid_Target":   {target("linux", "amd64", ""), target("linux", "s390x", "v1")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			model := CompileDataSourceModel{
+				BuildModel: BuildModel{
+					Source:      types.StringValue("main.go"),
+					Destination: types.StringValue(filepath.Join(t.TempDir(), "cli")),
+				},
+				Targets: targets,
+			}
+
+			diags := model.BuildTargets(context.Background(), nil)
+			assert.True(t, diags.HasError())
+
+			// The valid targets are neither hashed nor built if any target is invalid.
+			mockHasher.AssertNotCalled(t, "HashDir", mock.Anything, mock.Anything)
+			mockCompiler.AssertNotCalled(t, "Compile", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// The test replaces the global instances and can therefore not run in parallel.
+func TestAccCompileDataSourceTimeout(t *testing.T) {
+	compilerBackup, packagersBackup, hasherBackup := globalCompiler, globalPackagers, globalHasher
+	t.Cleanup(func() {
+		globalCompiler, globalPackagers, globalHasher = compilerBackup, packagersBackup, hasherBackup
+	})
+
+	sourceDir := t.TempDir()
+	source := filepath.Join(sourceDir, "main.go")
+	destination := filepath.Join(t.TempDir(), "binary")
+	assert.NoError(t, os.WriteFile(source, []byte("package main\n\nfunc main() {}\n"), 0o600))
+
+	// The mock compiler hangs until the deadline if it is shorter than a minute.
+	shortDeadline := mock.MatchedBy(func(ctx context.Context) bool {
+		deadline, ok := ctx.Deadline()
+
+		return ok && time.Until(deadline) < time.Minute
+	})
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Compile", shortDeadline, mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done() //nolint:forcetypeassert
+	}).Return("", compiler.ErrBuildTimedOut)
+	mockCompiler.On("Compile", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		conf := args.Get(1).(compiler.Config) //nolint:forcetypeassert
+		assert.NoError(t, os.WriteFile(conf.GetDestination(), []byte("binary"), 0o600))
+	}).Return(destination, nil)
+	mockCompiler.On("Ports", mock.Anything, "").Return(testPorts, nil)
+	globalCompiler = &mockCompiler
+	globalPackagers = packager.Packagers()
+	globalHasher = hasher.New()
+
+	testAccProtoV6ProviderFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"gopackager": providerserver.NewProtocol6WithError(New("test")()),
+	}
+
+	timeoutConfig := func(read string) string {
+		return fmt.Sprintf(`
+data "gopackager_compile" "test" {
+	source = %q
+	destination = %q
+	goos = "linux"
+	goarch = "amd64"
+
+	timeouts {
+		read = %q
+	}
+}
+		`, source, destination, read)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      timeoutConfig("1s"),
+				ExpectError: regexp.MustCompile("Build timed out"),
+			},
+			{
+				Config: timeoutConfig("10m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "output_path", destination),
+					resource.TestCheckResourceAttr("data.gopackager_compile.test", "timeouts.read", "10m"),
+				),
+			},
+		},
+	})
 }
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/stevencyb/gopackager/internal/compiler"
+)
+
+// GoModulePathFunction is the function to get the path of a Go module.
+type GoModulePathFunction struct{}
+
+// NewGoModulePathFunction creates a new function instance.
+func NewGoModulePathFunction() function.Function {
+	return &GoModulePathFunction{}
+}
+
+// Sets the function metadata.
+func (g *GoModulePathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "go_module_path"
+}
+
+// Sets the function definition.
+func (g *GoModulePathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the path of the Go module containing a directory.",
+		MarkdownDescription: "Returns the module path declared in the `go.mod` of the directory or of its closest parent directory (e.g. `github.com/stevencyb/gopackager`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "dir",
+				MarkdownDescription: "Directory inside of the module.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Reads the module path.
+func (g *GoModulePathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var dir string
+
+	resp.Error = req.Arguments.Get(ctx, &dir)
+	if resp.Error != nil {
+		return
+	}
+
+	modulePath, err := compiler.ModulePath(dir)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Reading the module of '"+dir+"' failed with: '"+err.Error()+"'.")
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, modulePath)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccGoModulePathFunction(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "cmd", "cli"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.24\n"), 0o600))
+
+	run := func(dir string) function.RunResponse {
+		resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		NewGoModulePathFunction().Run(context.Background(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(dir)}),
+		}, &resp)
+
+		return resp
+	}
+
+	t.Run("Sub_Directory", func(t *testing.T) {
+		t.Parallel()
+
+		resp := run(filepath.Join(dir, "cmd", "cli"))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.StringValue("example.com/app"), resp.Result.Value())
+	})
+
+	t.Run("No_Module", func(t *testing.T) {
+		t.Parallel()
+
+		resp := run(t.TempDir())
+		assert.NotNil(t, resp.Error)
+		assert.Contains(t, resp.Error.Text, "go.mod not found")
+	})
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stevencyb/gopackager/internal/hasher"
+)
+
+// HashDirFunction is the function to hash a directory without building.
+type HashDirFunction struct{}
+
+// NewHashDirFunction creates a new function instance.
+func NewHashDirFunction() function.Function {
+	return &HashDirFunction{}
+}
+
+// Sets the function metadata.
+func (h *HashDirFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "hash_dir"
+}
+
+// Sets the function definition.
+func (h *HashDirFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Hashes the files of a directory.",
+		MarkdownDescription: "Hashes the files of a directory like `output_*` of `gopackager_compile` with `hash_mode = \"dir\"`, without the build settings. " +
+			"Files ignored by `.gitignore` or `.dockerignore` are excluded. " +
+			"Returns the hashes by algorithm as hex and by algorithm with the suffix `_base64` base64 encoded (e.g. `sha256_base64`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path of the directory to hash.",
+			},
+			function.ListParameter{
+				Name:                "excludes",
+				MarkdownDescription: "Patterns in the format of `.gitignore` relative to the directory of files to exclude (e.g. `dist/` or `*.log`), can be `null`.",
+				ElementType:         types.StringType,
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.MapReturn{ElementType: types.StringType},
+	}
+}
+
+// Hashes the directory.
+func (h *HashDirFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+	var excludes types.List
+
+	resp.Error = req.Arguments.Get(ctx, &path, &excludes)
+	if resp.Error != nil {
+		return
+	}
+
+	opts := hasher.DirOptions{IgnoreFiles: defaultHashIgnoreFiles}
+	if diags := excludes.ElementsAs(ctx, &opts.Excludes, false); diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+
+		return
+	}
+
+	combinedHashes, err := globalHasher.HashDir(path, opts)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Hashing '"+path+"' failed with: '"+err.Error()+"'.")
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, hashesValue(combinedHashes))
+}
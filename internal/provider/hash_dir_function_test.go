@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stevencyb/gopackager/internal/hasher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccHashDirFunction(t *testing.T) {
+	hasherBackup := globalHasher
+	t.Cleanup(func() {
+		globalHasher = hasherBackup
+	})
+
+	globalHasher = hasher.New()
+
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "dist"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "dist", "binary"), []byte("binary"), 0o600))
+
+	run := func(excludes types.List) function.RunResponse {
+		resp := function.RunResponse{Result: function.NewResultData(types.MapUnknown(types.StringType))}
+		NewHashDirFunction().Run(context.Background(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(root), excludes}),
+		}, &resp)
+
+		return resp
+	}
+
+	t.Run("Excludes", func(t *testing.T) {
+		expected, err := globalHasher.HashDir(root, hasher.DirOptions{IgnoreFiles: defaultHashIgnoreFiles, Excludes: []string{"dist/"}})
+		assert.NoError(t, err)
+
+		resp := run(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("dist/")}))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, hashesValue(expected), resp.Result.Value())
+	})
+
+	t.Run("Null_Excludes", func(t *testing.T) {
+		expected, err := globalHasher.HashDir(root, hasher.DirOptions{IgnoreFiles: defaultHashIgnoreFiles})
+		assert.NoError(t, err)
+
+		resp := run(types.ListNull(types.StringType))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, hashesValue(expected), resp.Result.Value())
+		assert.Contains(t, resp.Result.Value().(types.Map).Elements(), hasher.AlgorithmSHA256+hasher.Base64Suffix)
+	})
+
+	t.Run("Missing_Directory", func(t *testing.T) {
+		resp := function.RunResponse{Result: function.NewResultData(types.MapUnknown(types.StringType))}
+		NewHashDirFunction().Run(context.Background(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(filepath.Join(root, "missing")), types.ListNull(types.StringType)}),
+		}, &resp)
+		assert.NotNil(t, resp.Error)
+		assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+	})
+}
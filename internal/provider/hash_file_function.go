@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// HashFileFunction is the function to hash a single file.
+type HashFileFunction struct{}
+
+// NewHashFileFunction creates a new function instance.
+func NewHashFileFunction() function.Function {
+	return &HashFileFunction{}
+}
+
+// Sets the function metadata.
+func (h *HashFileFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "hash_file"
+}
+
+// Sets the function definition.
+func (h *HashFileFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Hashes the content of a file.",
+		MarkdownDescription: "Hashes the content of a file like `artifact_*` of `gopackager_compile`. " +
+			"Returns the hashes by algorithm as hex and by algorithm with the suffix `_base64` base64 encoded (e.g. `sha256_base64`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path of the file to hash.",
+			},
+		},
+		Return: function.MapReturn{ElementType: types.StringType},
+	}
+}
+
+// Hashes the file.
+func (h *HashFileFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+
+	resp.Error = req.Arguments.Get(ctx, &path)
+	if resp.Error != nil {
+		return
+	}
+
+	combinedHashes, err := globalHasher.HashFile(path, nil)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Reading '"+path+"' failed with: '"+err.Error()+"'.")
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, hashesValue(combinedHashes))
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stevencyb/gopackager/internal/hasher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccHashFileFunction(t *testing.T) {
+	hasherBackup := globalHasher
+	t.Cleanup(func() {
+		globalHasher = hasherBackup
+	})
+
+	globalHasher = hasher.New()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("test"), 0o600))
+
+	run := func(path string) function.RunResponse {
+		resp := function.RunResponse{Result: function.NewResultData(types.MapUnknown(types.StringType))}
+		NewHashFileFunction().Run(context.Background(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(path)}),
+		}, &resp)
+
+		return resp
+	}
+
+	t.Run("File", func(t *testing.T) {
+		resp := run(path)
+		assert.Nil(t, resp.Error)
+
+		elements := resp.Result.Value().(types.Map).Elements()
+		assert.Len(t, elements, 2*len(hasher.DefaultAlgorithms))
+		assert.Equal(t, types.StringValue("098f6bcd4621d373cade4e832627b4f6"), elements[hasher.AlgorithmMD5])
+		assert.Equal(t, types.StringValue("n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="), elements[hasher.AlgorithmSHA256+hasher.Base64Suffix])
+	})
+
+	t.Run("Missing_File", func(t *testing.T) {
+		resp := run(path + ".missing")
+		assert.NotNil(t, resp.Error)
+		assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+	})
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stevencyb/gopackager/internal/hasher"
+)
+
+// manifestEntryType is the type of the entries of `source_manifest`.
+var manifestEntryType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"digest": types.StringType,
+	"size":   types.Int64Type,
+	"mode":   types.StringType,
+}}
+
+// ManifestEntryModel is the model for a hashed file in `source_manifest`.
+type ManifestEntryModel struct {
+	Digest types.String `tfsdk:"digest"`
+	Size   types.Int64  `tfsdk:"size"`
+	Mode   types.String `tfsdk:"mode"`
+}
+
+// manifestFile is the content of the `source_manifest_file`.
+type manifestFile struct {
+	Files []manifestFileEntry `json:"files"`
+}
+
+// manifestFileEntry is a hashed file in the `source_manifest_file`.
+type manifestFileEntry struct {
+	Path   string `json:"path"`
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+	Mode   string `json:"mode"`
+}
+
+// fileMode formats the permissions of the file like `0644`.
+func fileMode(mode os.FileMode) string {
+	return fmt.Sprintf("%04o", mode.Perm())
+}
+
+// SetSourceManifest sets `source_manifest` to the hashed files if `source_manifest_enabled` is set.
+// The files are keyed by their path, so the plan shows which files changed.
+func (b *BuildModel) SetSourceManifest(ctx context.Context, files []hasher.FileDigest) diag.Diagnostics {
+	if !b.SourceManifestEnabled.ValueBool() {
+		b.SourceManifest = types.MapNull(manifestEntryType)
+
+		return nil
+	}
+
+	entries := make(map[string]ManifestEntryModel, len(files))
+	for _, file := range files {
+		entries[file.Path] = ManifestEntryModel{
+			Digest: types.StringValue(hex.EncodeToString(file.Digest)),
+			Size:   types.Int64Value(file.Size),
+			Mode:   types.StringValue(fileMode(file.Mode)),
+		}
+	}
+
+	manifest, diags := types.MapValueFrom(ctx, manifestEntryType, entries)
+	b.SourceManifest = manifest
+
+	return diags
+}
+
+// writeSourceManifest writes the hashed files as JSON to the `source_manifest_file` if it is set.
+func (b *BuildModel) writeSourceManifest(files []hasher.FileDigest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	manifestPath := b.SourceManifestFile.ValueString()
+	if manifestPath == "" {
+		return diags
+	}
+
+	manifest := manifestFile{Files: make([]manifestFileEntry, 0, len(files))}
+	for _, file := range files {
+		manifest.Files = append(manifest.Files, manifestFileEntry{
+			Path:   file.Path,
+			Digest: hex.EncodeToString(file.Digest),
+			Size:   file.Size,
+			Mode:   fileMode(file.Mode),
+		})
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(manifestPath), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(manifestPath, append(content, '\n'), 0o644)
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			fwpath.Root("source_manifest_file"),
+			"Unable to write source manifest.",
+			"Writing '"+manifestPath+"' failed with: '"+err.Error()+"'.",
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stevencyb/gopackager/internal/compiler"
+	"golang.org/x/mod/modfile"
+)
+
+// ModuleDataSourceModel is the model for the module data source.
+type ModuleDataSourceModel struct {
+	// Input
+	Path types.String `tfsdk:"path"`
+	// Output
+	File       types.String   `tfsdk:"file"`
+	ModulePath types.String   `tfsdk:"module_path"`
+	GoVersion  types.String   `tfsdk:"go_version"`
+	Toolchain  types.String   `tfsdk:"toolchain"`
+	Requires   []RequireModel `tfsdk:"requires"`
+	Replaces   []ReplaceModel `tfsdk:"replaces"`
+	Retracts   []RetractModel `tfsdk:"retracts"`
+}
+
+// RequireModel is the model for a `require` of the module.
+type RequireModel struct {
+	Path     types.String `tfsdk:"path"`
+	Version  types.String `tfsdk:"version"`
+	Indirect types.Bool   `tfsdk:"indirect"`
+}
+
+// ReplaceModel is the model for a `replace` of the module.
+type ReplaceModel struct {
+	OldPath    types.String `tfsdk:"old_path"`
+	OldVersion types.String `tfsdk:"old_version"`
+	NewPath    types.String `tfsdk:"new_path"`
+	NewVersion types.String `tfsdk:"new_version"`
+}
+
+// RetractModel is the model for a `retract` of the module.
+type RetractModel struct {
+	Low       types.String `tfsdk:"low"`
+	High      types.String `tfsdk:"high"`
+	Rationale types.String `tfsdk:"rationale"`
+}
+
+// setModule sets the outputs to the parsed `go.mod`.
+// Optional directives and versions that are not set are null.
+func (m *ModuleDataSourceModel) setModule(file *modfile.File) {
+	m.File = types.StringValue(file.Syntax.Name)
+	m.ModulePath = types.StringValue(file.Module.Mod.Path)
+	m.GoVersion = types.StringNull()
+	if file.Go != nil {
+		m.GoVersion = types.StringValue(file.Go.Version)
+	}
+	m.Toolchain = types.StringNull()
+	if file.Toolchain != nil {
+		m.Toolchain = types.StringValue(file.Toolchain.Name)
+	}
+
+	m.Requires = make([]RequireModel, 0, len(file.Require))
+	for _, require := range file.Require {
+		m.Requires = append(m.Requires, RequireModel{
+			Path:     types.StringValue(require.Mod.Path),
+			Version:  types.StringValue(require.Mod.Version),
+			Indirect: types.BoolValue(require.Indirect),
+		})
+	}
+
+	m.Replaces = make([]ReplaceModel, 0, len(file.Replace))
+	for _, replace := range file.Replace {
+		m.Replaces = append(m.Replaces, ReplaceModel{
+			OldPath:    types.StringValue(replace.Old.Path),
+			OldVersion: optionalString(replace.Old.Version),
+			NewPath:    types.StringValue(replace.New.Path),
+			NewVersion: optionalString(replace.New.Version),
+		})
+	}
+
+	m.Retracts = make([]RetractModel, 0, len(file.Retract))
+	for _, retract := range file.Retract {
+		m.Retracts = append(m.Retracts, RetractModel{
+			Low:       types.StringValue(retract.Low),
+			High:      types.StringValue(retract.High),
+			Rationale: optionalString(retract.Rationale),
+		})
+	}
+}
+
+// optionalString returns the value, which is null if it is empty.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// ModuleDataSource is the data source to read a `go.mod`.
+type ModuleDataSource struct{}
+
+// NewModuleDataSource creates a new data source instance.
+func NewModuleDataSource() datasource.DataSource {
+	return &ModuleDataSource{}
+}
+
+// Sets the provider metadata.
+func (m *ModuleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_module"
+}
+
+// Sets the provider schema.
+func (m *ModuleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	description := `Reads the module path, the Go version and the dependencies of a Go module from its go.mod file.`
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: description,
+		Description:         description,
+
+		Attributes: map[string]schema.Attribute{
+			// Required input
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the `go.mod` or of a directory of the module, whose `go.mod` is looked up in the directory and its parents.",
+				Required:            true,
+			},
+			// Output
+			"file": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Absolute path of the read `go.mod`.",
+			},
+			"module_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Path of the module (e.g. `github.com/stevencyb/gopackager`).",
+			},
+			"go_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Minimum Go version of the `go` directive (e.g. `1.24.0`), null if not set.",
+			},
+			"toolchain": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Suggested toolchain of the `toolchain` directive (e.g. `go1.25.1`), null if not set.",
+			},
+			"requires": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Required modules in the order of the `go.mod`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Path of the required module.",
+						},
+						"version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Minimum version of the required module.",
+						},
+						"indirect": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the module is marked with `// indirect`, as it isn't imported by the main module.",
+						},
+					},
+				},
+			},
+			"replaces": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Replaced modules in the order of the `go.mod`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"old_path": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Path of the replaced module.",
+						},
+						"old_version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Replaced version, null if all versions are replaced.",
+						},
+						"new_path": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Path of the replacement module or local directory.",
+						},
+						"new_version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Version of the replacement module, null for a local directory.",
+						},
+					},
+				},
+			},
+			"retracts": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Retracted versions of the module in the order of the `go.mod`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"low": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Lowest retracted version.",
+						},
+						"high": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Highest retracted version, which is the same as `low` for a single version.",
+						},
+						"rationale": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Reason of the retraction from its comment, null if there is none.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read event for this data source.
+func (m *ModuleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ModuleDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	file, err := compiler.ReadModule(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			fwpath.Root("path"),
+			"Unable to read go.mod.",
+			"Reading the module of '"+data.Path.ValueString()+"' failed with: '"+err.Error()+"'.",
+		)
+
+		return
+	}
+
+	data.setModule(file)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
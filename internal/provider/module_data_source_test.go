@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stevencyb/gopackager/internal/compiler"
+	"github.com/stretchr/testify/assert"
+)
+
+// testGoMod is a go.mod with all directives exposed by the module data source.
+const testGoMod = `module example.com/app
+
+go 1.24.0
+
+toolchain go1.25.1
+
+require (
+	example.com/direct v1.2.3
+	example.com/indirect v0.1.0 // indirect
+)
+
+replace example.com/direct => ../direct
+
+replace example.com/indirect v0.1.0 => example.com/fork v0.2.0
+
+retract v1.0.1
+
+retract [v1.0.0, v1.0.5] // Broken build.
+`
+
+func TestAccModuleDataSourceFrameworkSatisfaction(t *testing.T) {
+	t.Parallel()
+
+	var _ datasource.DataSource = &ModuleDataSource{}
+}
+
+func TestAccModuleDataSourceModel(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(testGoMod), 0o600))
+
+	file, err := compiler.ReadModule(dir)
+	assert.NoError(t, err)
+
+	model := ModuleDataSourceModel{}
+	model.setModule(file)
+	assert.Equal(t, ModuleDataSourceModel{
+		File:       types.StringValue(filepath.Join(dir, "go.mod")),
+		ModulePath: types.StringValue("example.com/app"),
+		GoVersion:  types.StringValue("1.24.0"),
+		Toolchain:  types.StringValue("go1.25.1"),
+		Requires: []RequireModel{
+			{Path: types.StringValue("example.com/direct"), Version: types.StringValue("v1.2.3"), Indirect: types.BoolValue(false)},
+			{Path: types.StringValue("example.com/indirect"), Version: types.StringValue("v0.1.0"), Indirect: types.BoolValue(true)},
+		},
+		Replaces: []ReplaceModel{
+			{OldPath: types.StringValue("example.com/direct"), OldVersion: types.StringNull(), NewPath: types.StringValue("../direct"), NewVersion: types.StringNull()},
+			{OldPath: types.StringValue("example.com/indirect"), OldVersion: types.StringValue("v0.1.0"), NewPath: types.StringValue("example.com/fork"), NewVersion: types.StringValue("v0.2.0")},
+		},
+		Retracts: []RetractModel{
+			{Low: types.StringValue("v1.0.1"), High: types.StringValue("v1.0.1"), Rationale: types.StringNull()},
+			{Low: types.StringValue("v1.0.0"), High: types.StringValue("v1.0.5"), Rationale: types.StringValue("Broken build.")},
+		},
+	}, model)
+
+	// Optional directives are null and the lists empty.
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0o600))
+	file, err = compiler.ReadModule(dir)
+	assert.NoError(t, err)
+
+	model.setModule(file)
+	assert.True(t, model.GoVersion.IsNull())
+	assert.True(t, model.Toolchain.IsNull())
+	assert.Empty(t, model.Requires)
+	assert.NotNil(t, model.Requires)
+}
+
+func TestAccModuleDataSource(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "cmd", "cli"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(testGoMod), 0o600))
+
+	testAccProtoV6ProviderFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"gopackager": providerserver.NewProtocol6WithError(New("test")()),
+	}
+
+	moduleConfig := func(path string) string {
+		return fmt.Sprintf(`
+data "gopackager_module" "test" {
+	path = %q
+}
+		`, path)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: moduleConfig(filepath.Join(dir, "cmd", "cli")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gopackager_module.test", "file", filepath.Join(dir, "go.mod")),
+					resource.TestCheckResourceAttr("data.gopackager_module.test", "module_path", "example.com/app"),
+					resource.TestCheckResourceAttr("data.gopackager_module.test", "go_version", "1.24.0"),
+					resource.TestCheckResourceAttr("data.gopackager_module.test", "toolchain", "go1.25.1"),
+					resource.TestCheckResourceAttr("data.gopackager_module.test", "requires.#", "2"),
+					resource.TestCheckResourceAttr("data.gopackager_module.test", "requires.1.path", "example.com/indirect"),
+					resource.TestCheckResourceAttr("data.gopackager_module.test", "requires.1.indirect", "true"),
+					resource.TestCheckResourceAttr("data.gopackager_module.test", "replaces.0.new_path", "../direct"),
+					resource.TestCheckNoResourceAttr("data.gopackager_module.test", "replaces.0.new_version"),
+					resource.TestCheckResourceAttr("data.gopackager_module.test", "retracts.1.rationale", "Broken build."),
+				),
+			},
+			{
+				Config:      moduleConfig(filepath.Join(dir, "missing", "go.mod")),
+				ExpectError: regexp.MustCompile("go.mod not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/stevencyb/gopackager/internal/compiler"
+)
+
+// providerGoBinary returns the go binary of the provider defaults, which is empty for the go binary on the PATH.
+func providerGoBinary(defaults *ProviderDefaults) string {
+	if defaults == nil {
+		return ""
+	}
+
+	return defaults.GoBinary
+}
+
+// validatePlatformResolvable reports GOOS and GOARCH that are neither set nor defaulted by the provider,
+// as the build would only fail on apply otherwise. Unknown values are skipped, as they are resolved on apply.
+func validatePlatformResolvable(defaults *ProviderDefaults, goos, goarch types.String, goosPath, goarchPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if defaults == nil {
+		defaults = &ProviderDefaults{}
+	}
+
+	if goos.IsNull() && defaults.GOOS == "" {
+		diags.AddAttributeError(
+			goosPath,
+			"Missing GOOS.",
+			"GOOS must be set, either on the attribute or as `goos` of the provider.",
+		)
+	}
+
+	if goarch.IsNull() && defaults.GOARCH == "" {
+		diags.AddAttributeError(
+			goarchPath,
+			"Missing GOARCH.",
+			"GOARCH must be set, either on the attribute or as `goarch` of the provider.",
+		)
+	}
+
+	return diags
+}
+
+// validatePlatform validates GOOS and GOARCH against the ports of the toolchain
+// and suggests the closest supported value on the attribute path.
+// Null and unknown values are skipped, as well as the validation if the toolchain
+// can not be queried, since the build reports a missing toolchain anyway.
+func validatePlatform(ctx context.Context, goBinary string, goos, goarch types.String, goosPath, goarchPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	goosSet := !goos.IsNull() && !goos.IsUnknown()
+	goarchSet := !goarch.IsNull() && !goarch.IsUnknown()
+	if !goosSet && !goarchSet {
+		return diags
+	}
+
+	ports, err := globalCompiler.Ports(ctx, goBinary)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to validate GOOS and GOARCH: "+err.Error())
+
+		return diags
+	}
+
+	gooses := ports.GOOSes()
+	if goosSet && !slices.Contains(gooses, goos.ValueString()) {
+		diags.AddAttributeError(
+			goosPath,
+			"Unsupported GOOS.",
+			"GOOS '"+goos.ValueString()+"' is not supported by the toolchain. Did you mean '"+compiler.Closest(goos.ValueString(), gooses)+"'?",
+		)
+
+		return diags
+	}
+
+	if !goarchSet {
+		return diags
+	}
+
+	goarches := []string{}
+	if goosSet {
+		goarches = ports.GOARCHes(goos.ValueString())
+	} else {
+		for _, goos := range gooses {
+			for _, goarch := range ports.GOARCHes(goos) {
+				if !slices.Contains(goarches, goarch) {
+					goarches = append(goarches, goarch)
+				}
+			}
+		}
+	}
+
+	if !slices.Contains(goarches, goarch.ValueString()) {
+		detail := "GOARCH '" + goarch.ValueString() + "' is not supported by the toolchain."
+		if goosSet {
+			detail = "GOARCH '" + goarch.ValueString() + "' is not supported by the toolchain for GOOS '" + goos.ValueString() + "'."
+		}
+
+		diags.AddAttributeError(
+			goarchPath,
+			"Unsupported GOARCH.",
+			detail+" Did you mean '"+compiler.Closest(goarch.ValueString(), goarches)+"'?",
+		)
+	}
+
+	return diags
+}
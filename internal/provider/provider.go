@@ -3,14 +3,47 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stevencyb/gopackager/internal/hasher"
 )
 
 // GoPackagerProviderModel describes the provider data model.
-type GoPackagerProviderModel struct{}
+type GoPackagerProviderModel struct {
+	GOOS            types.String `tfsdk:"goos"`
+	GOARCH          types.String `tfsdk:"goarch"`
+	GoBinary        types.String `tfsdk:"go_binary"`
+	BuildFlags      types.List   `tfsdk:"build_flags"`
+	Env             types.Map    `tfsdk:"env"`
+	BuildCacheDir   types.String `tfsdk:"build_cache_dir"`
+	ArtifactDir     types.String `tfsdk:"artifact_dir"`
+	HashConcurrency types.Int64  `tfsdk:"hash_concurrency"`
+	HashCacheDir    types.String `tfsdk:"hash_cache_dir"`
+	HashCacheReset  types.String `tfsdk:"hash_cache_reset"`
+}
+
+// ProviderDefaults are the defaults of the provider configuration,
+// which are passed to the data sources and resources and overridden by their values.
+type ProviderDefaults struct {
+	GOOS            string
+	GOARCH          string
+	GoBinary        string
+	BuildFlags      []string
+	Env             map[string]string
+	BuildCacheDir   string
+	ArtifactDir     string
+	HashConcurrency int
+	HashCacheDir    string
+}
 
 // GoPackagerProvider defines the provider implementation.
 type GoPackagerProvider struct {
@@ -41,6 +74,57 @@ func (g *GoPackagerProvider) Schema(ctx context.Context, req provider.SchemaRequ
 	resp.Schema = schema.Schema{
 		MarkdownDescription: description,
 		Description:         description,
+
+		Attributes: map[string]schema.Attribute{
+			"goos": schema.StringAttribute{
+				MarkdownDescription: "Default GOOS for all builds.",
+				Optional:            true,
+			},
+			"goarch": schema.StringAttribute{
+				MarkdownDescription: "Default GOARCH for all builds.",
+				Optional:            true,
+			},
+			"go_binary": schema.StringAttribute{
+				MarkdownDescription: "Path or name of the go binary, which is looked up in the `PATH`. Defaults to `go`.",
+				Optional:            true,
+			},
+			"build_flags": schema.ListAttribute{
+				MarkdownDescription: "Default additional flags passed to `go build` (e.g. `-v`). Flags of a block replace these flags.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"env": schema.MapAttribute{
+				MarkdownDescription: "Default environment variables for all builds. Variables of a block are merged into these variables.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"build_cache_dir": schema.StringAttribute{
+				MarkdownDescription: "Build cache directory (`GOCACHE`) shared by all builds.",
+				Optional:            true,
+			},
+			"artifact_dir": schema.StringAttribute{
+				MarkdownDescription: "Root directory for relative destinations.",
+				Optional:            true,
+			},
+			"hash_concurrency": schema.Int64Attribute{
+				MarkdownDescription: "Number of source files hashed in parallel. Defaults to the number of CPUs.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"hash_cache_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory to cache the digests of source files in between runs, so only files with a changed inode, size, modification time or mode are hashed again. " +
+					"The cache is safe to share between concurrent runs and to delete at any time. Caching is disabled if not set.",
+				Optional: true,
+			},
+			"hash_cache_reset": schema.StringAttribute{
+				MarkdownDescription: "Key to invalidate the `hash_cache_dir`, e.g. after restoring files with their original modification time (e.g. `\"2024-01-31\"`). " +
+					"The cached digests are removed once whenever the key differs from the one of the last reset, which is stored in the `hash_cache_dir`. " +
+					"Other files in the `hash_cache_dir` are kept.",
+				Optional: true,
+			},
+		},
 	}
 }
 
@@ -53,17 +137,114 @@ func (g *GoPackagerProvider) Configure(ctx context.Context, req provider.Configu
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	defaults, diags := data.Defaults(ctx)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resetKey := data.HashCacheReset.ValueString(); resetKey != "" && defaults.HashCacheDir != "" {
+		if _, err := hasher.ResetCache(defaults.HashCacheDir, resetKey); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("hash_cache_reset"),
+				"Unable to reset the hash cache.",
+				"Resetting '"+defaults.HashCacheDir+"' failed with: '"+err.Error()+"'.",
+			)
+
+			return
+		}
+	}
+
+	resp.DataSourceData = defaults
+	resp.ResourceData = defaults
+}
+
+// ValidateConfig validates the default GOOS and GOARCH against the ports of the toolchain.
+func (g *GoPackagerProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var data GoPackagerProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.GoBinary.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePlatform(ctx, data.GoBinary.ValueString(), data.GOOS, data.GOARCH, path.Root("goos"), path.Root("goarch"))...)
 }
 
 // Resources returns the provider resources.
-// Currently there aren't any resources for this provider.
 func (g *GoPackagerProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return []func() resource.Resource{}
+	return []func() resource.Resource{
+		NewBinaryResource,
+	}
 }
 
 // DataSources returns the provider data sources.
 func (g *GoPackagerProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewCompilerDataSource,
+		NewModuleDataSource,
+		NewToolchainDataSource,
+	}
+}
+
+// Functions returns the provider functions.
+func (g *GoPackagerProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewHashDirFunction,
+		NewHashFileFunction,
+		NewGoModulePathFunction,
+	}
+}
+
+// Defaults converts the provider configuration into the defaults for the data sources and resources.
+// Values that are unknown during the plan are rejected, as they would change the source hashes after planning.
+func (g *GoPackagerProviderModel) Defaults(ctx context.Context) (*ProviderDefaults, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for name, value := range map[string]attr.Value{
+		"goos":             g.GOOS,
+		"goarch":           g.GOARCH,
+		"go_binary":        g.GoBinary,
+		"build_flags":      g.BuildFlags,
+		"env":              g.Env,
+		"build_cache_dir":  g.BuildCacheDir,
+		"artifact_dir":     g.ArtifactDir,
+		"hash_concurrency": g.HashConcurrency,
+		"hash_cache_dir":   g.HashCacheDir,
+		"hash_cache_reset": g.HashCacheReset,
+	} {
+		if value.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Unknown provider configuration.",
+				"The value of '"+name+"' must be known when configuring the provider.",
+			)
+		}
 	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	defaults := &ProviderDefaults{
+		GOOS:            g.GOOS.ValueString(),
+		GOARCH:          g.GOARCH.ValueString(),
+		GoBinary:        g.GoBinary.ValueString(),
+		BuildCacheDir:   g.BuildCacheDir.ValueString(),
+		ArtifactDir:     g.ArtifactDir.ValueString(),
+		HashConcurrency: int(g.HashConcurrency.ValueInt64()),
+		HashCacheDir:    g.HashCacheDir.ValueString(),
+	}
+
+	if !g.BuildFlags.IsNull() {
+		defaults.BuildFlags = []string{}
+		diags.Append(g.BuildFlags.ElementsAs(ctx, &defaults.BuildFlags, false)...)
+	}
+
+	if !g.Env.IsNull() {
+		defaults.Env = map[string]string{}
+		diags.Append(g.Env.ElementsAs(ctx, &defaults.Env, false)...)
+	}
+
+	return defaults, diags
 }
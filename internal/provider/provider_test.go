@@ -1,13 +1,138 @@
 package provider
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccProviderFrameworkSatisfaction(t *testing.T) {
 	t.Parallel()
 
 	var _ provider.Provider = &GoPackagerProvider{}
+	var _ provider.ProviderWithValidateConfig = &GoPackagerProvider{}
+	var _ provider.ProviderWithFunctions = &GoPackagerProvider{}
+}
+
+func TestAccProviderDefaults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		model := GoPackagerProviderModel{
+			BuildFlags: types.ListNull(types.StringType),
+			Env:        types.MapNull(types.StringType),
+		}
+
+		defaults, diags := model.Defaults(context.Background())
+		assert.False(t, diags.HasError())
+		assert.Equal(t, &ProviderDefaults{}, defaults)
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		t.Parallel()
+
+		buildFlags, diags := types.ListValueFrom(context.Background(), types.StringType, []string{"-v"})
+		assert.False(t, diags.HasError())
+		env, diags := types.MapValueFrom(context.Background(), types.StringType, map[string]string{"GOAMD64": "v3"})
+		assert.False(t, diags.HasError())
+
+		model := GoPackagerProviderModel{
+			GOOS:            types.StringValue("linux"),
+			GOARCH:          types.StringValue("amd64"),
+			GoBinary:        types.StringValue("go1.24"),
+			BuildFlags:      buildFlags,
+			Env:             env,
+			BuildCacheDir:   types.StringValue("/tmp/gocache"),
+			ArtifactDir:     types.StringValue("/tmp/artifacts"),
+			HashConcurrency: types.Int64Value(4),
+			HashCacheDir:    types.StringValue("/tmp/hashes"),
+		}
+
+		defaults, diags := model.Defaults(context.Background())
+		assert.False(t, diags.HasError())
+		assert.Equal(t, &ProviderDefaults{
+			GOOS:            "linux",
+			GOARCH:          "amd64",
+			GoBinary:        "go1.24",
+			BuildFlags:      []string{"-v"},
+			Env:             map[string]string{"GOAMD64": "v3"},
+			BuildCacheDir:   "/tmp/gocache",
+			ArtifactDir:     "/tmp/artifacts",
+			HashConcurrency: 4,
+			HashCacheDir:    "/tmp/hashes",
+		}, defaults)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		t.Parallel()
+
+		model := GoPackagerProviderModel{
+			GOOS:       types.StringUnknown(),
+			BuildFlags: types.ListNull(types.StringType),
+			Env:        types.MapNull(types.StringType),
+		}
+
+		_, diags := model.Defaults(context.Background())
+		assert.True(t, diags.HasError())
+	})
+}
+
+// The functions are called through the provider server like Terraform does, so the test doesn't require Terraform.
+func TestAccProviderFunctions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gopackagerProvider := New("test")()
+
+	functions, ok := gopackagerProvider.(provider.ProviderWithFunctions)
+	assert.True(t, ok)
+
+	names := []string{}
+	for _, newFunction := range functions.Functions(ctx) {
+		fn := newFunction()
+
+		metadataResp := &function.MetadataResponse{}
+		fn.Metadata(ctx, function.MetadataRequest{}, metadataResp)
+		names = append(names, metadataResp.Name)
+
+		definitionResp := &function.DefinitionResponse{}
+		fn.Definition(ctx, function.DefinitionRequest{}, definitionResp)
+		validateResp := &function.DefinitionValidateResponse{}
+		definitionResp.Definition.ValidateImplementation(ctx, function.DefinitionValidateRequest{FuncName: metadataResp.Name}, validateResp)
+		assert.False(t, validateResp.Diagnostics.HasError(), validateResp.Diagnostics)
+	}
+	assert.ElementsMatch(t, []string{"hash_dir", "hash_file", "go_module_path"}, names)
+
+	server, err := providerserver.NewProtocol6WithError(gopackagerProvider)()
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.24\n"), 0o600))
+	argument, err := tfprotov6.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, dir))
+	assert.NoError(t, err)
+
+	resp, err := server.CallFunction(ctx, &tfprotov6.CallFunctionRequest{Name: "go_module_path", Arguments: []*tfprotov6.DynamicValue{&argument}})
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Error)
+
+	result, err := resp.Result.Unmarshal(tftypes.String)
+	assert.NoError(t, err)
+	assert.True(t, result.Equal(tftypes.NewValue(tftypes.String, "example.com/app")), result)
+
+	// Errors are reported on the argument.
+	resp, err = server.CallFunction(ctx, &tfprotov6.CallFunctionRequest{Name: "hash_file", Arguments: []*tfprotov6.DynamicValue{&argument}})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp.Error)
+	assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
 }
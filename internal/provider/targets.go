@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/stevencyb/gopackager/internal/compiler"
+)
+
+// TargetModel is the model for a target of the build matrix.
+type TargetModel struct {
+	GOOS    types.String `tfsdk:"goos"`
+	GOARCH  types.String `tfsdk:"goarch"`
+	Variant types.String `tfsdk:"variant"`
+}
+
+// Key returns the key of the target in `target_outputs` (e.g. `linux_amd64` or `linux_arm_7`).
+func (t TargetModel) Key() string {
+	key := t.GOOS.ValueString() + "_" + t.GOARCH.ValueString()
+	if variant := t.Variant.ValueString(); variant != "" {
+		key += "_" + variant
+	}
+
+	return key
+}
+
+// TargetOutputModel is the model for the outputs of a target.
+type TargetOutputModel struct {
+	OutputPath           types.String `tfsdk:"output_path"`
+	ArtifactMD5          types.String `tfsdk:"artifact_md5"`
+	ArtifactSHA1         types.String `tfsdk:"artifact_sha1"`
+	ArtifactSHA256       types.String `tfsdk:"artifact_sha256"`
+	ArtifactSHA512       types.String `tfsdk:"artifact_sha512"`
+	ArtifactSHA256Base64 types.String `tfsdk:"artifact_sha256_base64"`
+	ArtifactSHA512Base64 types.String `tfsdk:"artifact_sha512_base64"`
+	ArtifactHashes       types.Map    `tfsdk:"artifact_hashes"`
+}
+
+// BuildTargets compiles the binary for every target in parallel, limited by `concurrency`.
+// Each binary is placed in a directory named by the key of the target next to the destination
+// (e.g. `dist/cli` results in `dist/linux_amd64/cli`). The source files are hashed only once.
+func (c *CompileDataSourceModel) BuildTargets(ctx context.Context, defaults *ProviderDefaults) diag.Diagnostics {
+	tflog.Trace(ctx, "Checking configuration")
+
+	base, diags := c.Config(ctx, defaults)
+	if diags.HasError() {
+		return diags
+	}
+
+	models := make([]BuildModel, len(c.Targets))
+	targetDiags := make([]diag.Diagnostics, len(c.Targets))
+	confs := make([]*compiler.Config, len(c.Targets))
+	keys := map[string]int{}
+
+	// All targets are validated before any is hashed or built, so an invalid target doesn't leave partial artifacts.
+	for i, target := range c.Targets {
+		targetPath := fwpath.Root("targets").AtListIndex(i)
+
+		key := target.Key()
+		if index, exists := keys[key]; exists {
+			diags.AddAttributeError(
+				targetPath,
+				"Duplicate target.",
+				"Target '"+key+"' is already defined at "+fwpath.Root("targets").AtListIndex(index).String()+".",
+			)
+
+			continue
+		}
+
+		keys[key] = i
+
+		conf, confDiags := c.Config(ctx, defaults)
+		if diags.Append(confDiags...); diags.HasError() {
+			return diags
+		}
+
+		conf = conf.
+			Destination(filepath.Join(filepath.Dir(base.GetDestination()), key, filepath.Base(base.GetDestination()))).
+			GOOS(target.GOOS.ValueString()).
+			GOARCH(target.GOARCH.ValueString()).
+			Variant(target.Variant.ValueString())
+		if err := conf.Verify(); err != nil {
+			diags.AddAttributeError(
+				targetPath,
+				"Invalid configuration.",
+				"Expected configuration of target '"+key+"' to be valid, but got '"+err.Error()+"'.",
+			)
+
+			continue
+		}
+
+		confs[i] = conf
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	// The sources are hashed once for all targets, which are built from the union of their files.
+	tflog.Trace(ctx, "Compute hashes")
+	dirHashes, hashDiags := c.hashSources(ctx, defaults, confs...)
+	if diags.Append(hashDiags...); diags.HasError() {
+		return diags
+	}
+
+	concurrency := runtime.NumCPU()
+	if !c.Concurrency.IsNull() && !c.Concurrency.IsUnknown() {
+		concurrency = int(c.Concurrency.ValueInt64())
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, conf := range confs {
+		models[i] = c.BuildModel
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			tflog.Trace(ctx, "Building target "+c.Targets[i].Key())
+			targetDiags[i] = models[i].compile(ctx, conf, c.archiveFormat(), dirHashes)
+		}()
+	}
+
+	wg.Wait()
+
+	outputs := map[string]TargetOutputModel{}
+	for i, target := range c.Targets {
+		// Attach the diagnostics to the target, as the messages are the same for all targets.
+		for _, d := range targetDiags[i] {
+			if d.Severity() == diag.SeverityError {
+				diags.AddAttributeError(fwpath.Root("targets").AtListIndex(i), d.Summary(), d.Detail())
+			} else {
+				diags.AddAttributeWarning(fwpath.Root("targets").AtListIndex(i), d.Summary(), d.Detail())
+			}
+		}
+
+		if models[i].OutputPath.IsNull() {
+			continue
+		}
+
+		outputs[target.Key()] = TargetOutputModel{
+			OutputPath:           models[i].OutputPath,
+			ArtifactMD5:          models[i].ArtifactMD5,
+			ArtifactSHA1:         models[i].ArtifactSHA1,
+			ArtifactSHA256:       models[i].ArtifactSHA256,
+			ArtifactSHA512:       models[i].ArtifactSHA512,
+			ArtifactSHA256Base64: models[i].ArtifactSHA256Base64,
+			ArtifactSHA512Base64: models[i].ArtifactSHA512Base64,
+			ArtifactHashes:       models[i].ArtifactHashes,
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	// The top level outputs only describe the source, as the artifacts are target specific.
+	sourceHashes, saltDiags := c.saltHashes(ctx, dirHashes, base)
+	if diags.Append(saltDiags...); diags.HasError() {
+		return diags
+	}
+
+	c.SetSourceHashes(sourceHashes)
+	c.TargetOutputs = outputs
+	if diags.Append(c.SetSourceManifest(ctx, sourceHashes.Files)...); diags.HasError() {
+		return diags
+	}
+
+	diags.Append(c.writeSourceManifest(sourceHashes.Files)...)
+
+	return diags
+}
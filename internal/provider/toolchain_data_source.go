@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stevencyb/gopackager/internal/compiler"
+)
+
+// ToolchainDataSourceModel is the model for the toolchain data source.
+type ToolchainDataSourceModel struct {
+	// Optional
+	GoBinary types.String `tfsdk:"go_binary"`
+	// Output
+	Executable types.String `tfsdk:"executable"`
+	Version    types.String `tfsdk:"version"`
+	GoVersion  types.String `tfsdk:"go_version"`
+	GOROOT     types.String `tfsdk:"goroot"`
+	GOPATH     types.String `tfsdk:"gopath"`
+	GOMODCACHE types.String `tfsdk:"gomodcache"`
+	GOFLAGS    types.String `tfsdk:"goflags"`
+	Ports      []PortModel  `tfsdk:"ports"`
+}
+
+// PortModel is the model for a port supported by the toolchain.
+type PortModel struct {
+	GOOS         types.String `tfsdk:"goos"`
+	GOARCH       types.String `tfsdk:"goarch"`
+	CgoSupported types.Bool   `tfsdk:"cgo_supported"`
+	FirstClass   types.Bool   `tfsdk:"first_class"`
+}
+
+// Config creates the compiler configuration to describe the toolchain like for builds.
+// The go binary of the model overrides the provider default.
+func (t *ToolchainDataSourceModel) Config(defaults *ProviderDefaults) *compiler.Config {
+	if defaults == nil {
+		defaults = &ProviderDefaults{}
+	}
+
+	conf := compiler.NewConfig().
+		GOOS(defaults.GOOS).
+		GOARCH(defaults.GOARCH).
+		GoBinary(valueOrDefault(t.GoBinary, defaults.GoBinary)).
+		CacheDir(defaults.BuildCacheDir)
+	if defaults.Env != nil {
+		conf = conf.Env(defaults.Env)
+	}
+
+	return conf
+}
+
+// setToolchain sets the outputs to the described toolchain and its ports.
+func (t *ToolchainDataSourceModel) setToolchain(toolchain *compiler.Toolchain, ports compiler.Ports) {
+	t.Executable = types.StringValue(toolchain.Executable)
+	t.Version = types.StringValue(toolchain.Version)
+	t.GoVersion = types.StringValue(toolchain.Env["GOVERSION"])
+	t.GOROOT = types.StringValue(toolchain.Env["GOROOT"])
+	t.GOPATH = types.StringValue(toolchain.Env["GOPATH"])
+	t.GOMODCACHE = types.StringValue(toolchain.Env["GOMODCACHE"])
+	t.GOFLAGS = types.StringValue(toolchain.Env["GOFLAGS"])
+
+	t.Ports = make([]PortModel, 0, len(ports))
+	for _, port := range ports {
+		t.Ports = append(t.Ports, PortModel{
+			GOOS:         types.StringValue(port.GOOS),
+			GOARCH:       types.StringValue(port.GOARCH),
+			CgoSupported: types.BoolValue(port.CgoSupported),
+			FirstClass:   types.BoolValue(port.FirstClass),
+		})
+	}
+}
+
+// Describe describes the toolchain and sets all outputs.
+func (t *ToolchainDataSourceModel) Describe(ctx context.Context, defaults *ProviderDefaults) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conf := t.Config(defaults)
+
+	toolchain, err := globalCompiler.Toolchain(ctx, *conf)
+	if err != nil {
+		diags.AddAttributeError(
+			fwpath.Root("go_binary"),
+			"Unable to describe toolchain.",
+			"Running go failed with: '"+err.Error()+"'.",
+		)
+
+		return diags
+	}
+
+	ports, err := globalCompiler.Ports(ctx, conf.GetGoBinary())
+	if err != nil {
+		diags.AddAttributeError(
+			fwpath.Root("go_binary"),
+			"Unable to list ports.",
+			"Listing the ports of the toolchain failed with: '"+err.Error()+"'.",
+		)
+
+		return diags
+	}
+
+	t.setToolchain(toolchain, ports)
+
+	return diags
+}
+
+// ToolchainDataSource is the data source to describe the go toolchain.
+type ToolchainDataSource struct {
+	defaults *ProviderDefaults
+}
+
+// NewToolchainDataSource creates a new data source instance.
+func NewToolchainDataSource() datasource.DataSource {
+	return &ToolchainDataSource{}
+}
+
+// Sets the provider metadata.
+func (t *ToolchainDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_toolchain"
+}
+
+// Sets the provider schema.
+func (t *ToolchainDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	description := `Describes the GoLang toolchain used for builds via go version and go env, e.g. to check its version in a precondition.` +
+		` The go binary is run with the environment of the provider like for builds.`
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: description,
+		Description:         description,
+
+		Attributes: map[string]schema.Attribute{
+			// Optional input
+			"go_binary": schema.StringAttribute{
+				MarkdownDescription: "Path or name of the go binary. Defaults to the `go_binary` of the provider.",
+				Optional:            true,
+			},
+			// Output
+			"executable": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resolved path of the go binary.",
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Output of `go version` (e.g. `go version go1.24.1 linux/amd64`).",
+			},
+			"go_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version of the toolchain from `GOVERSION` (e.g. `go1.24.1`).",
+			},
+			"goroot": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Root of the toolchain from `GOROOT`.",
+			},
+			"gopath": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Workspace of the toolchain from `GOPATH`.",
+			},
+			"gomodcache": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Module cache of the toolchain from `GOMODCACHE`.",
+			},
+			"goflags": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Default flags of the go command from `GOFLAGS`, which is empty if not set.",
+			},
+			"ports": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Ports supported by the toolchain (`go tool dist list`).",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"goos": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "GOOS of the port.",
+						},
+						"goarch": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "GOARCH of the port.",
+						},
+						"cgo_supported": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether cgo is supported for the port.",
+						},
+						"first_class": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the port is a first class port of the Go project.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configures the provider.
+func (t *ToolchainDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	defaults, ok := req.ProviderData.(*ProviderDefaults)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data.",
+			fmt.Sprintf("Expected *ProviderDefaults, but got '%T'.", req.ProviderData),
+		)
+
+		return
+	}
+
+	t.defaults = defaults
+}
+
+// Read event for this data source.
+func (t *ToolchainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ToolchainDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Diagnostics.Append(data.Describe(ctx, t.defaults)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
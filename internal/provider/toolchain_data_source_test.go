@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stevencyb/gopackager/internal/compiler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccToolchainDataSourceFrameworkSatisfaction(t *testing.T) {
+	t.Parallel()
+
+	var _ datasource.DataSource = &ToolchainDataSource{}
+	var _ datasource.DataSourceWithConfigure = &ToolchainDataSource{}
+}
+
+func TestAccToolchainDataSourceModelConfig(t *testing.T) {
+	t.Parallel()
+
+	defaults := &ProviderDefaults{
+		GOOS:          "linux",
+		GOARCH:        "arm64",
+		GoBinary:      "go1.24",
+		Env:           map[string]string{"GOFLAGS": "-mod=mod"},
+		BuildCacheDir: "/tmp/gocache",
+	}
+
+	model := ToolchainDataSourceModel{GoBinary: types.StringNull()}
+	assert.Equal(t, compiler.NewConfig().
+		GOOS("linux").
+		GOARCH("arm64").
+		GoBinary("go1.24").
+		CacheDir("/tmp/gocache").
+		Env(map[string]string{"GOFLAGS": "-mod=mod"}), model.Config(defaults))
+
+	model.GoBinary = types.StringValue("/usr/local/go/bin/go")
+	assert.Equal(t, "/usr/local/go/bin/go", model.Config(defaults).GetGoBinary())
+
+	model.GoBinary = types.StringNull()
+	assert.Equal(t, compiler.NewConfig(), model.Config(nil))
+}
+
+// The test replaces the global compiler and can therefore not run in parallel.
+func TestAccToolchainDataSourceModelDescribe(t *testing.T) {
+	compilerBackup := globalCompiler
+	t.Cleanup(func() {
+		globalCompiler = compilerBackup
+	})
+
+	mockCompiler := compiler.MockCompiler{}
+	mockCompiler.On("Toolchain", mock.Anything, *compiler.NewConfig().GoBinary("go1.24")).Return(&compiler.Toolchain{
+		Executable: "/usr/local/bin/go1.24",
+		Version:    "go version go1.24.1 linux/amd64",
+		Env: map[string]string{
+			"GOVERSION":  "go1.24.1",
+			"GOROOT":     "/usr/local/go",
+			"GOPATH":     "/home/user/go",
+			"GOMODCACHE": "/home/user/go/pkg/mod",
+			"GOFLAGS":    "",
+		},
+	}, nil)
+	mockCompiler.On("Toolchain", mock.Anything, mock.Anything).Return(nil, compiler.ErrGoBinaryNotFound)
+	mockCompiler.On("Ports", mock.Anything, "go1.24").Return(testPorts[:2], nil)
+	globalCompiler = &mockCompiler
+
+	model := ToolchainDataSourceModel{GoBinary: types.StringNull()}
+	assert.False(t, model.Describe(context.Background(), &ProviderDefaults{GoBinary: "go1.24"}).HasError())
+	assert.Equal(t, ToolchainDataSourceModel{
+		GoBinary:   types.StringNull(),
+		Executable: types.StringValue("/usr/local/bin/go1.24"),
+		Version:    types.StringValue("go version go1.24.1 linux/amd64"),
+		GoVersion:  types.StringValue("go1.24.1"),
+		GOROOT:     types.StringValue("/usr/local/go"),
+		GOPATH:     types.StringValue("/home/user/go"),
+		GOMODCACHE: types.StringValue("/home/user/go/pkg/mod"),
+		GOFLAGS:    types.StringValue(""),
+		Ports: []PortModel{
+			{GOOS: types.StringValue("linux"), GOARCH: types.StringValue("amd64"), CgoSupported: types.BoolValue(false), FirstClass: types.BoolValue(false)},
+			{GOOS: types.StringValue("linux"), GOARCH: types.StringValue("arm"), CgoSupported: types.BoolValue(false), FirstClass: types.BoolValue(false)},
+		},
+	}, model)
+
+	model = ToolchainDataSourceModel{GoBinary: types.StringValue("go-does-not-exist")}
+	diags := model.Describe(context.Background(), nil)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), compiler.ErrGoBinaryNotFound.Error())
+}
+
+// The test replaces the global compiler and can therefore not run in parallel.
+func TestAccToolchainDataSource(t *testing.T) {
+	compilerBackup := globalCompiler
+	t.Cleanup(func() {
+		globalCompiler = compilerBackup
+	})
+
+	globalCompiler = compiler.New()
+
+	testAccProtoV6ProviderFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"gopackager": providerserver.NewProtocol6WithError(New("test")()),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "gopackager_toolchain" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.gopackager_toolchain.test", "executable"),
+					resource.TestCheckResourceAttrSet("data.gopackager_toolchain.test", "go_version"),
+					resource.TestCheckResourceAttrSet("data.gopackager_toolchain.test", "goroot"),
+					resource.TestCheckResourceAttrSet("data.gopackager_toolchain.test", "gomodcache"),
+					resource.TestCheckResourceAttrSet("data.gopackager_toolchain.test", "ports.0.goos"),
+				),
+			},
+		},
+	})
+}
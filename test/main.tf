@@ -27,6 +27,25 @@ data "gopackager_compile" "example_local_zip" {
   }
 }
 
+data "gopackager_compile" "example_local_tar_gz" {
+  source      = "../main.go"
+  destination = "build/c/bootstrap"
+  goarch      = "amd64"
+  goos        = "linux"
+
+  archive_format = "tar.gz"
+  zip_resources = {
+    "../README.md" = "README.md"
+  }
+}
+
+resource "gopackager_binary" "example_local_resource" {
+  source      = "../main.go"
+  destination = "build/d/bootstrap"
+  goarch      = "amd64"
+  goos        = "linux"
+}
+
 # Outputs
 output "example_local" {
   value = {